@@ -0,0 +1,110 @@
+package camera
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/canvas"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"sarim-tracer/features/world"
+)
+
+// Camera : maps the three-dimensional scene onto a two-dimensional canvas
+//
+// the canvas is always one unit in front of the camera. the transform is
+// set through SetTransform, so that its inverse is only computed once rather
+// than for every ray. a camera built without CameraNew or SetTransform has
+// the identity transform
+type Camera struct {
+	HSize, VSize          int
+	FieldOfView           float64
+	transform             *mat.Dense
+	inverse               *mat.Dense
+	halfWidth, halfHeight float64
+	pixelSize             float64
+}
+
+// CameraNew : camera constructor
+//
+// using variadic function to make transform optional
+func CameraNew(hsize, vsize int, fieldOfView float64, transform ...*mat.Dense) Camera {
+	c := Camera{HSize: hsize, VSize: vsize, FieldOfView: fieldOfView}
+	c.SetTransform(transformations.IdentityNew(4))
+	if len(transform) > 0 {
+		c.SetTransform(transform[0])
+	}
+	// the canvas is one unit away, so half of its width is tan(fov / 2)
+	halfView := math.Tan(fieldOfView / 2)
+	aspect := float64(hsize) / float64(vsize)
+	if aspect >= 1 {
+		c.halfWidth = halfView
+		c.halfHeight = halfView / aspect
+	} else {
+		c.halfWidth = halfView * aspect
+		c.halfHeight = halfView
+	}
+	c.pixelSize = (c.halfWidth * 2) / float64(hsize)
+	return c
+}
+
+// PixelSize : size of a single pixel on the canvas, in world units
+func (c Camera) PixelSize() float64 {
+	return c.pixelSize
+}
+
+// GetTransform : get a copy of the view transform of the camera
+//
+// changing the copy does not change the camera, use SetTransform instead
+func (c Camera) GetTransform() *mat.Dense {
+	if c.transform == nil {
+		return transformations.IdentityNew(4)
+	}
+	return mat.DenseCopyOf(c.transform)
+}
+
+// SetTransform : set the view transform of the camera, and cache its inverse
+//
+// the camera keeps a copy, so later changes to transform don't leave the
+// cached inverse out of date
+func (c *Camera) SetTransform(transform *mat.Dense) {
+	c.transform = mat.DenseCopyOf(transform)
+	c.inverse = transformations.IdentityNew(4)
+	c.inverse.Inverse(transform)
+}
+
+// RayForPixel : create a ray from the camera through the center of a pixel
+func (c Camera) RayForPixel(px, py int) rays.Ray {
+	// offset from the edge of the canvas to the pixel's center
+	xOffset := (float64(px) + 0.5) * c.pixelSize
+	yOffset := (float64(py) + 0.5) * c.pixelSize
+	// untransformed coordinates of the pixel in world space
+	// (the camera looks toward -z, so +x is to the left)
+	worldX := c.halfWidth - xOffset
+	worldY := c.halfHeight - yOffset
+	// transform the canvas point and the origin, then compute the direction
+	pixel := tuples.PointNew(worldX, worldY, -1)
+	origin := tuples.PointNew(0, 0, 0)
+	// without a transform the camera sits at the origin, as for the identity
+	if c.inverse != nil {
+		pixel = pixel.Transform(c.inverse)
+		origin = origin.Transform(c.inverse)
+	}
+	direction := pixel.Subtract(origin).Normalize()
+	return rays.RayNew(origin, direction)
+}
+
+// Render : render an image of the world
+//
+// pixel (0, 0) is the top left of the image, so write the canvas without
+// flipping
+func (c Camera) Render(w world.World) canvas.Canvas {
+	image := canvas.CanvasNew(c.HSize, c.VSize)
+	for y := 0; y < c.VSize; y++ {
+		for x := 0; x < c.HSize; x++ {
+			r := c.RayForPixel(x, y)
+			image.SetPixel(x, y, w.ColorAt(r))
+		}
+	}
+	return image
+}
@@ -0,0 +1,134 @@
+package camera
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"sarim-tracer/features/world"
+	"testing"
+)
+
+func TestCameraNew(t *testing.T) {
+	c := CameraNew(160, 120, math.Pi/2)
+	if c.HSize != 160 {
+		t.Errorf("got %d want %d", c.HSize, 160)
+	}
+	if c.VSize != 120 {
+		t.Errorf("got %d want %d", c.VSize, 120)
+	}
+	if !tuples.FloatEqual(c.FieldOfView, math.Pi/2) {
+		t.Errorf("got %f want %f", c.FieldOfView, math.Pi/2)
+	}
+	if !mat.Equal(c.GetTransform(), transformations.IdentityNew(4)) {
+		t.Errorf("got %v want %v", c.GetTransform(), transformations.IdentityNew(4))
+	}
+}
+
+func TestPixelSize(t *testing.T) {
+	// horizontal canvas
+	c := CameraNew(200, 125, math.Pi/2)
+	if !tuples.FloatEqual(c.PixelSize(), 0.01) {
+		t.Errorf("got %f want %f", c.PixelSize(), 0.01)
+	}
+	// vertical canvas
+	c = CameraNew(125, 200, math.Pi/2)
+	if !tuples.FloatEqual(c.PixelSize(), 0.01) {
+		t.Errorf("got %f want %f", c.PixelSize(), 0.01)
+	}
+}
+
+func TestRayForPixel(t *testing.T) {
+	// through the center of the canvas
+	c := CameraNew(201, 101, math.Pi/2)
+	r := c.RayForPixel(100, 50)
+	if !r.Origin.Equal(tuples.PointNew(0, 0, 0)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 0, 0))
+	}
+	if !r.Direction.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", r.Direction, tuples.VectorNew(0, 0, -1))
+	}
+
+	// through a corner of the canvas
+	r = c.RayForPixel(0, 0)
+	if !r.Origin.Equal(tuples.PointNew(0, 0, 0)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 0, 0))
+	}
+	if !r.Direction.Equal(tuples.VectorNew(0.66519, 0.33259, -0.66851)) {
+		t.Errorf("got %v want %v", r.Direction, tuples.VectorNew(0.66519, 0.33259, -0.66851))
+	}
+}
+
+func TestRayForPixelTransformed(t *testing.T) {
+	c := CameraNew(201, 101, math.Pi/2, transformations.ChainTransform(
+		transformations.RotationYNew(math.Pi/4),
+		transformations.TranslationNew(0, -2, 5)))
+	r := c.RayForPixel(100, 50)
+	if !r.Origin.Equal(tuples.PointNew(0, 2, -5)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 2, -5))
+	}
+	want := tuples.VectorNew(math.Sqrt(2)/2, 0, -math.Sqrt(2)/2)
+	if !r.Direction.Equal(want) {
+		t.Errorf("got %v want %v", r.Direction, want)
+	}
+}
+
+// rays should follow a transform set after the camera was built
+func TestRayForPixelSetTransform(t *testing.T) {
+	c := CameraNew(201, 101, math.Pi/2)
+	c.SetTransform(transformations.TranslationNew(0, -2, 5))
+	r := c.RayForPixel(100, 50)
+	if !r.Origin.Equal(tuples.PointNew(0, 2, -5)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 2, -5))
+	}
+	want := tuples.VectorNew(0, 0, -1)
+	if !r.Direction.Equal(want) {
+		t.Errorf("got %v want %v", r.Direction, want)
+	}
+}
+
+// changing a transform after it was set, or after it was read, doesn't
+// change the camera
+func TestSetTransformCopies(t *testing.T) {
+	transform := transformations.TranslationNew(0, -2, 5)
+	c := CameraNew(201, 101, math.Pi/2)
+	c.SetTransform(transform)
+	transform.Set(0, 3, 7)
+	c.GetTransform().Set(1, 3, 7)
+	if !mat.Equal(c.GetTransform(), transformations.TranslationNew(0, -2, 5)) {
+		t.Errorf("got %v want %v", c.GetTransform(), transformations.TranslationNew(0, -2, 5))
+	}
+	r := c.RayForPixel(100, 50)
+	if !r.Origin.Equal(tuples.PointNew(0, 2, -5)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 2, -5))
+	}
+}
+
+// a camera built without CameraNew acts as if it had the identity transform
+func TestRayForPixelZeroCamera(t *testing.T) {
+	c := Camera{HSize: 1, VSize: 1}
+	if !mat.Equal(c.GetTransform(), transformations.IdentityNew(4)) {
+		t.Errorf("got %v want %v", c.GetTransform(), transformations.IdentityNew(4))
+	}
+	r := c.RayForPixel(0, 0)
+	if !r.Origin.Equal(tuples.PointNew(0, 0, 0)) {
+		t.Errorf("got %v want %v", r.Origin, tuples.PointNew(0, 0, 0))
+	}
+	if !r.Direction.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", r.Direction, tuples.VectorNew(0, 0, -1))
+	}
+}
+
+func TestRender(t *testing.T) {
+	w := world.DefaultWorldNew()
+	from := tuples.PointNew(0, 0, -5)
+	to := tuples.PointNew(0, 0, 0)
+	up := tuples.VectorNew(0, 1, 0)
+	c := CameraNew(11, 11, math.Pi/2, transformations.ViewTransform(from, to, up))
+	image := c.Render(w)
+	got := image.GetPixel(5, 5)
+	want := tuples.ColorNew(0.38066, 0.47583, 0.2855)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package canvas
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sarim-tracer/features/tuples"
+	"strconv"
+)
+
+// Width : the width of the canvas in pixels
+func (c Canvas) Width() int {
+	return c.width
+}
+
+// Height : the height of the canvas in pixels
+func (c Canvas) Height() int {
+	return c.height
+}
+
+// CanvasFromPPM : read a PPM or PGM image into a canvas
+//
+// the plain (P2, P3) and raw (P5, P6) formats are supported. gray PGM pixels
+// become gray colors, and every channel is scaled to 0-1 by the maximum value
+// in the header
+func CanvasFromPPM(r io.Reader) (Canvas, error) {
+	br := bufio.NewReader(r)
+	magic, err := readToken(br)
+	if err != nil {
+		return Canvas{}, err
+	}
+	channels := 3
+	switch magic {
+	case "P2", "P5":
+		channels = 1
+	case "P3", "P6":
+	default:
+		return Canvas{}, fmt.Errorf("unsupported format %q", magic)
+	}
+	var header [3]int
+	for i, name := range []string{"width", "height", "maximum value"} {
+		token, err := readToken(br)
+		if err != nil {
+			return Canvas{}, fmt.Errorf("reading %s: %w", name, err)
+		}
+		header[i], err = strconv.Atoi(token)
+		if err != nil || header[i] <= 0 {
+			return Canvas{}, fmt.Errorf("invalid %s %q", name, token)
+		}
+	}
+	width, height, maxValue := header[0], header[1], header[2]
+	if maxValue > 65535 {
+		return Canvas{}, fmt.Errorf("invalid maximum value %d", maxValue)
+	}
+	if width > math.MaxInt32/height {
+		return Canvas{}, fmt.Errorf("image too large, %dx%d", width, height)
+	}
+	// raw formats have exactly one whitespace byte between header and body,
+	// which reading the maximum value has already consumed
+	raw := magic == "P5" || magic == "P6"
+	// the header alone could ask for any size, so pixels are only kept as
+	// they are read, and the canvas is made once they all turn out to exist
+	pixels := []tuples.Tuple{}
+	sample := make([]float64, channels)
+	for j := 0; j < height; j++ {
+		for i := 0; i < width; i++ {
+			for k := range sample {
+				var value int
+				if raw {
+					value, err = readRawSample(br, maxValue)
+				} else {
+					value, err = readPlainSample(br)
+				}
+				if err != nil {
+					return Canvas{}, fmt.Errorf("pixel %d, %d: %w", i, j, err)
+				}
+				sample[k] = float64(value) / float64(maxValue)
+			}
+			if channels == 1 {
+				pixels = append(pixels, tuples.ColorNew(sample[0], sample[0], sample[0]))
+			} else {
+				pixels = append(pixels, tuples.ColorNew(sample[0], sample[1], sample[2]))
+			}
+		}
+	}
+	c := CanvasNew(width, height)
+	for n, pixel := range pixels {
+		c.SetPixel(n%width, n/width, pixel)
+	}
+	return c, nil
+}
+
+// CanvasFromPPMPath : read a PPM or PGM file into a canvas
+func CanvasFromPPMPath(path string) (Canvas, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Canvas{}, err
+	}
+	defer f.Close()
+	return CanvasFromPPM(f)
+}
+
+// readToken : read the next whitespace separated word, skipping # comments
+func readToken(br *bufio.Reader) (string, error) {
+	token := []byte{}
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF && len(token) > 0 {
+			return string(token), nil
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+		switch {
+		case b == '#' && len(token) == 0:
+			// comments run to the end of the line
+			if _, err := br.ReadString('\n'); err != nil && err != io.EOF {
+				return "", err
+			}
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == '\v' || b == '\f':
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, b)
+		}
+	}
+}
+
+// readPlainSample : read one channel written as a decimal number
+func readPlainSample(br *bufio.Reader) (int, error) {
+	token, err := readToken(br)
+	if err != nil {
+		return 0, err
+	}
+	value, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample %q", token)
+	}
+	return value, nil
+}
+
+// readRawSample : read one channel written as a byte, or as two big endian
+// bytes if the maximum value needs them
+func readRawSample(br *bufio.Reader, maxValue int) (int, error) {
+	size := 1
+	if maxValue > 255 {
+		size = 2
+	}
+	value := 0
+	for i := 0; i < size; i++ {
+		b, err := br.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				err = errors.New("unexpected end of image data")
+			}
+			return 0, err
+		}
+		value = value<<8 | int(b)
+	}
+	return value, nil
+}
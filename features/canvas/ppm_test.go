@@ -0,0 +1,87 @@
+package canvas
+
+import (
+	"path/filepath"
+	"sarim-tracer/features/tuples"
+	"strings"
+	"testing"
+)
+
+func TestCanvasFromPPM(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		x, y   int
+		want   tuples.Tuple
+		width  int
+		height int
+	}{
+		{"plain ppm", "P3\n2 1\n255\n255 0 0 0 51 255\n", 1, 0, tuples.ColorNew(0, 0.2, 1), 2, 1},
+		{"plain ppm with comments", "P3\n# made by hand\n1 2 # size\n100\n0 0 0\n50 100 25\n", 0, 1, tuples.ColorNew(0.5, 1, 0.25), 1, 2},
+		{"plain pgm", "P2\n2 2\n10\n0 5\n10 0\n", 1, 0, tuples.ColorNew(0.5, 0.5, 0.5), 2, 2},
+		{"raw ppm", "P6\n1 1\n255\n\xff\x80\x00", 0, 0, tuples.ColorNew(1, 128.0/255, 0), 1, 1},
+		{"raw pgm", "P5 2 1 255\n\x00\x33", 1, 0, tuples.ColorNew(0.2, 0.2, 0.2), 2, 1},
+		{"raw 16 bit pgm", "P5\n1 1\n65535\n\x80\x00", 0, 0,
+			tuples.ColorNew(32768.0/65535, 32768.0/65535, 32768.0/65535), 1, 1},
+	}
+	for _, tt := range tests {
+		c, err := CanvasFromPPM(strings.NewReader(tt.data))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if c.Width() != tt.width || c.Height() != tt.height {
+			t.Errorf("%s: got %dx%d want %dx%d", tt.name, c.Width(), c.Height(), tt.width, tt.height)
+		}
+		got := c.GetPixel(tt.x, tt.y)
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCanvasFromPPMErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"bad magic", "P4\n1 1\n", "unsupported format \"P4\""},
+		{"bad width", "P3\nx 1\n255\n", "invalid width \"x\""},
+		{"short body", "P3\n2 1\n255\n1 2 3\n", "pixel 1, 0: unexpected EOF"},
+		{"bad sample", "P2\n1 1\n255\nabc\n", "pixel 0, 0: invalid sample \"abc\""},
+		{"short raw body", "P6\n1 1\n255\n\x01", "pixel 0, 0: unexpected end of image data"},
+		// the header size is only trusted once the pixels have been read
+		{"huge header", "P6\n40000 40000\n255\n\x01\x02\x03", "pixel 1, 0: unexpected end of image data"},
+		{"too large", "P5\n2147483647 2147483647\n255\n", "image too large, 2147483647x2147483647"},
+	}
+	for _, tt := range tests {
+		_, err := CanvasFromPPM(strings.NewReader(tt.data))
+		if err == nil {
+			t.Fatalf("%s: got no error want %q", tt.name, tt.want)
+		}
+		if err.Error() != tt.want {
+			t.Errorf("%s: got %q want %q", tt.name, err.Error(), tt.want)
+		}
+	}
+}
+
+// a canvas written with ToPPM should read back the same, to within rounding
+func TestCanvasPPMRoundTrip(t *testing.T) {
+	c := CanvasNew(3, 2)
+	c.SetPixel(0, 0, tuples.ColorNew(1, 0, 0))
+	c.SetPixel(2, 1, tuples.ColorNew(0, 0.5, 1))
+	path := filepath.Join(t.TempDir(), "roundtrip.ppm")
+	c.ToPPM(path, false, false)
+	got, err := CanvasFromPPMPath(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := 0; j < 2; j++ {
+		for i := 0; i < 3; i++ {
+			g, w := got.GetPixel(i, j), c.GetPixel(i, j)
+			if g.Subtract(w).Magnitude() > 1.0/255 {
+				t.Errorf("got %v want %v", g, w)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package challenges
+
+import (
+	"math"
+	"sarim-tracer/features/camera"
+	"sarim-tracer/features/lights"
+	"sarim-tracer/features/patterns"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"sarim-tracer/features/world"
+	"testing"
+)
+
+func TestDrawPlane(t *testing.T) {
+	// a matte floor and a backdrop behind the spheres
+	floor := shapes.PlaneNew()
+	floor.Material.Pattern = patterns.CheckersNew(tuples.ColorNew(1, 0.9, 0.9), tuples.ColorNew(0.5, 0.45, 0.45))
+	floor.Material.Specular = 0
+	floor.Material.Reflective = 0.3
+
+	backdrop := shapes.PlaneNew(transformations.ChainTransform(
+		transformations.TranslationNew(0, 0, 5),
+		transformations.RotationXNew(math.Pi/2)))
+	backdrop.Material.Pattern = patterns.StripeNew(tuples.ColorNew(0.9, 0.9, 1), tuples.ColorNew(0.7, 0.7, 0.9),
+		transformations.RotationYNew(math.Pi/4))
+	backdrop.Material.Specular = 0
+
+	middle := shapes.SphereNew(transformations.TranslationNew(-0.5, 1, 0.5))
+	middle.Material.Pattern = patterns.GradientNew(tuples.ColorNew(0.1, 1, 0.5), tuples.ColorNew(0.1, 0.5, 1),
+		transformations.ChainTransform(transformations.TranslationNew(-1, 0, 0), transformations.ScalingNew(2, 2, 2)))
+	middle.Material.Diffuse = 0.7
+	middle.Material.Specular = 0.3
+
+	right := shapes.SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(1.5, 0.5, -0.5),
+		transformations.ScalingNew(0.5, 0.5, 0.5)))
+	// a glass sphere on the right
+	right.Material.Color = tuples.ColorNew(0.1, 0.1, 0.1)
+	right.Material.Diffuse = 0.1
+	right.Material.Specular = 1
+	right.Material.Shininess = 300
+	right.Material.Reflective = 0.9
+	right.Material.Transparency = 0.9
+	right.Material.RefractiveIndex = 1.5
+
+	w := world.WorldNew()
+	w.Shapes = []shapes.Shape{floor, backdrop, middle, right}
+	w.Lights = []lights.PointLight{
+		lights.PointLightNew(tuples.PointNew(-10, 10, -10), tuples.ColorNew(1, 1, 1)),
+	}
+
+	c := camera.CameraNew(100, 50, math.Pi/3, transformations.ViewTransform(
+		tuples.PointNew(0, 1.5, -5),
+		tuples.PointNew(0, 1, 0),
+		tuples.VectorNew(0, 1, 0)))
+
+	// dump to image
+	image := c.Render(w)
+	image.ToPPM("plane_test.ppm", false, false)
+}
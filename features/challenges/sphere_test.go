@@ -2,50 +2,74 @@ package challenges
 
 import (
 	"math"
-	"sarim-tracer/features/canvas"
-	"sarim-tracer/features/rays"
+	"sarim-tracer/features/camera"
+	"sarim-tracer/features/lights"
+	"sarim-tracer/features/materials"
 	"sarim-tracer/features/shapes"
 	"sarim-tracer/features/transformations"
 	"sarim-tracer/features/tuples"
+	"sarim-tracer/features/world"
 	"testing"
 )
 
 func TestDrawSphere(t *testing.T) {
-	// start the ray at z = -5
-	rayOrigin := tuples.PointNew(0, 0, -5)
-	// put the wall at z = 10
-	wallZ := 10.0
-	wallSize := 7.0
-	// pixel size
-	canvasPixels := 100
-	pixelSize := wallSize / float64(canvasPixels)
-	half := wallSize / 2.0
-
-	canvas := canvas.CanvasNew(canvasPixels, canvasPixels)
-	s := shapes.SphereNew(transformations.ScalingNew(1, 0.5, 1), transformations.RotationXNew(math.Pi/4))
-
-	// for each row of pixels in canvas
-	for y := 0; y < canvasPixels-1; y++ {
-		// compute the world y coordinate
-		worldY := half - pixelSize*float64(y)
-		// for each pixel in the row
-		for x := 0; x < canvasPixels-1; x++ {
-			// compute the world x coordinate
-			worldX := -half + pixelSize*float64(x)
-			// describe point on wall that ray will target
-			position := tuples.PointNew(worldX, worldY, wallZ)
-
-			r := rays.RayNew(rayOrigin, position.Subtract(rayOrigin).Normalize())
-			xs := s.Intersect(r)
-
-			// if there is a hit, write pixel
-			if len(xs) > 0 {
-				canvas.SetPixel(x, y, tuples.ColorNew(1, 0, 0))
-			}
-		}
+	// the floor and walls are extremely flattened spheres with a matte texture
+	wallMaterial := materials.MaterialNew()
+	wallMaterial.Color = tuples.ColorNew(1, 0.9, 0.9)
+	wallMaterial.Specular = 0
+
+	floor := shapes.SphereNew(transformations.ScalingNew(10, 0.01, 10))
+	floor.Material = wallMaterial
+
+	leftWall := shapes.SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(0, 0, 5),
+		transformations.RotationYNew(-math.Pi/4),
+		transformations.RotationXNew(math.Pi/2),
+		transformations.ScalingNew(10, 0.01, 10)))
+	leftWall.Material = wallMaterial
+
+	rightWall := shapes.SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(0, 0, 5),
+		transformations.RotationYNew(math.Pi/4),
+		transformations.RotationXNew(math.Pi/2),
+		transformations.ScalingNew(10, 0.01, 10)))
+	rightWall.Material = wallMaterial
+
+	// a large green sphere in the middle
+	middle := shapes.SphereNew(transformations.TranslationNew(-0.5, 1, 0.5))
+	middle.Material.Color = tuples.ColorNew(0.1, 1, 0.5)
+	middle.Material.Diffuse = 0.7
+	middle.Material.Specular = 0.3
+
+	// a smaller green sphere on the right, scaled in half
+	right := shapes.SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(1.5, 0.5, -0.5),
+		transformations.ScalingNew(0.5, 0.5, 0.5)))
+	right.Material.Color = tuples.ColorNew(0.5, 1, 0.1)
+	right.Material.Diffuse = 0.7
+	right.Material.Specular = 0.3
+
+	// the smallest sphere, scaled by a third, before being translated
+	left := shapes.SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(-1.5, 0.33, -0.75),
+		transformations.ScalingNew(0.33, 0.33, 0.33)))
+	left.Material.Color = tuples.ColorNew(1, 0.8, 0.1)
+	left.Material.Diffuse = 0.7
+	left.Material.Specular = 0.3
+
+	w := world.WorldNew()
+	w.Shapes = []shapes.Shape{floor, leftWall, rightWall, middle, right, left}
+	// a white light source, shining from above and to the left
+	w.Lights = []lights.PointLight{
+		lights.PointLightNew(tuples.PointNew(-10, 10, -10), tuples.ColorNew(1, 1, 1)),
 	}
 
-	// dump to image
-	canvas.ToPPM("sphere_test.ppm", false, true)
+	c := camera.CameraNew(100, 50, math.Pi/3, transformations.ViewTransform(
+		tuples.PointNew(0, 1.5, -5),
+		tuples.PointNew(0, 1, 0),
+		tuples.VectorNew(0, 1, 0)))
 
+	// dump to image
+	image := c.Render(w)
+	image.ToPPM("sphere_test.ppm", false, false)
 }
@@ -0,0 +1,60 @@
+package lights
+
+import (
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+)
+
+// PointLight : a light source with no size, existing at a single point
+type PointLight struct {
+	Position  tuples.Tuple
+	Intensity tuples.Tuple
+}
+
+// PointLightNew : create a point light
+func PointLightNew(position, intensity tuples.Tuple) PointLight {
+	return PointLight{position, intensity}
+}
+
+// Lighting : shade a point using the Phong reflection model
+//
+// “Ambient reflection is background lighting, or light reflected from other
+// objects in the environment. Diffuse reflection is light reflected from a matte
+// surface. Specular reflection is the reflection of the light source itself and
+// results in what is called a specular highlight.”
+//
+// a point in shadow is lit by the ambient contribution only. the object is
+// needed to evaluate the material's pattern, if it has one
+func Lighting(material materials.Material, object shapes.Shape, light PointLight, point, eyev, normalv tuples.Tuple, inShadow bool) tuples.Tuple {
+	black := tuples.ColorNew(0, 0, 0)
+	color := material.Color
+	if material.Pattern != nil {
+		color = shapes.PatternAtShape(material.Pattern, object, point)
+	}
+	// combine the surface color with the light's color/intensity
+	effectiveColor := color.HadamardProduct(light.Intensity)
+	// find the direction to the light source
+	lightv := light.Position.Subtract(point).Normalize()
+	// compute the ambient contribution
+	ambient := tuples.ColorScalarMultiply(effectiveColor, material.Ambient)
+	// a negative cosine means the light is on the other side of the surface
+	lightDotNormal := lightv.DotProduct(normalv)
+	diffuse := black
+	specular := black
+	if !inShadow && lightDotNormal >= 0 {
+		// compute the diffuse contribution
+		diffuse = tuples.ColorScalarMultiply(effectiveColor, material.Diffuse*lightDotNormal)
+		// a negative cosine means the light reflects away from the eye
+		reflectv := lightv.Negate().Reflect(normalv)
+		reflectDotEye := reflectv.DotProduct(eyev)
+		if reflectDotEye > 0 {
+			// compute the specular contribution
+			factor := math.Pow(reflectDotEye, material.Shininess)
+			specular = tuples.ColorScalarMultiply(light.Intensity, material.Specular*factor)
+		}
+	}
+	// add the three contributions together to get the final shading
+	return tuples.ColorAdd(tuples.ColorAdd(ambient, diffuse), specular)
+}
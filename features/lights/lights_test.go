@@ -0,0 +1,121 @@
+package lights
+
+import (
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/patterns"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestPointLightNew(t *testing.T) {
+	intensity := tuples.ColorNew(1, 1, 1)
+	position := tuples.PointNew(0, 0, 0)
+	light := PointLightNew(position, intensity)
+	if !light.Position.Equal(position) {
+		t.Errorf("got %v want %v", light.Position, position)
+	}
+	if !light.Intensity.Equal(intensity) {
+		t.Errorf("got %v want %v", light.Intensity, intensity)
+	}
+}
+
+func TestLightingEyeBetweenLightAndSurface(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, 0, -1)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 0, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, false)
+	want := tuples.ColorNew(1.9, 1.9, 1.9)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingEyeOffset45(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, math.Sqrt(2)/2, -math.Sqrt(2)/2)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 0, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, false)
+	want := tuples.ColorNew(1.0, 1.0, 1.0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingLightOffset45(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, 0, -1)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 10, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, false)
+	want := tuples.ColorNew(0.7364, 0.7364, 0.7364)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingEyeInReflectionPath(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, -math.Sqrt(2)/2, -math.Sqrt(2)/2)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 10, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, false)
+	want := tuples.ColorNew(1.6364, 1.6364, 1.6364)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingLightBehindSurface(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, 0, -1)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 0, 10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, false)
+	want := tuples.ColorNew(0.1, 0.1, 0.1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingInShadow(t *testing.T) {
+	m := materials.MaterialNew()
+	position := tuples.PointNew(0, 0, 0)
+	eyev := tuples.VectorNew(0, 0, -1)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 0, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, position, eyev, normalv, true)
+	want := tuples.ColorNew(0.1, 0.1, 0.1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestLightingWithPattern(t *testing.T) {
+	m := materials.MaterialNew()
+	m.Pattern = patterns.StripeNew(tuples.ColorNew(1, 1, 1), tuples.ColorNew(0, 0, 0))
+	m.Ambient = 1
+	m.Diffuse = 0
+	m.Specular = 0
+	eyev := tuples.VectorNew(0, 0, -1)
+	normalv := tuples.VectorNew(0, 0, -1)
+	light := PointLightNew(tuples.PointNew(0, 0, -10), tuples.ColorNew(1, 1, 1))
+	got := Lighting(m, shapes.SphereNew(), light, tuples.PointNew(0.9, 0, 0), eyev, normalv, false)
+	want := tuples.ColorNew(1, 1, 1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = Lighting(m, shapes.SphereNew(), light, tuples.PointNew(1.1, 0, 0), eyev, normalv, false)
+	want = tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
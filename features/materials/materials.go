@@ -0,0 +1,38 @@
+package materials
+
+import (
+	"sarim-tracer/features/patterns"
+	"sarim-tracer/features/tuples"
+)
+
+// Material : surface attributes from the Phong reflection model
+//
+// when Pattern is set, it is used in place of Color
+type Material struct {
+	Color     tuples.Tuple
+	Pattern   patterns.Pattern
+	Ambient   float64
+	Diffuse   float64
+	Specular  float64
+	Shininess float64
+	// Reflective : 0 is non-reflective, 1 is a perfect mirror
+	Reflective float64
+	// Transparency : 0 is opaque, 1 lets all light through
+	Transparency float64
+	// RefractiveIndex : how much light bends entering the material, 1 is a vacuum
+	RefractiveIndex float64
+}
+
+// MaterialNew : create a material with the default attributes
+func MaterialNew() Material {
+	return Material{
+		Color:           tuples.ColorNew(1, 1, 1),
+		Ambient:         0.1,
+		Diffuse:         0.9,
+		Specular:        0.9,
+		Shininess:       200.0,
+		Reflective:      0.0,
+		Transparency:    0.0,
+		RefractiveIndex: 1.0,
+	}
+}
@@ -0,0 +1,34 @@
+package materials
+
+import (
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestMaterialNew(t *testing.T) {
+	m := MaterialNew()
+	if !m.Color.Equal(tuples.ColorNew(1, 1, 1)) {
+		t.Errorf("got %v want %v", m.Color, tuples.ColorNew(1, 1, 1))
+	}
+	if !tuples.FloatEqual(m.Ambient, 0.1) {
+		t.Errorf("got %f want %f", m.Ambient, 0.1)
+	}
+	if !tuples.FloatEqual(m.Diffuse, 0.9) {
+		t.Errorf("got %f want %f", m.Diffuse, 0.9)
+	}
+	if !tuples.FloatEqual(m.Specular, 0.9) {
+		t.Errorf("got %f want %f", m.Specular, 0.9)
+	}
+	if !tuples.FloatEqual(m.Shininess, 200.0) {
+		t.Errorf("got %f want %f", m.Shininess, 200.0)
+	}
+	if !tuples.FloatEqual(m.Reflective, 0.0) {
+		t.Errorf("got %f want %f", m.Reflective, 0.0)
+	}
+	if !tuples.FloatEqual(m.Transparency, 0.0) {
+		t.Errorf("got %f want %f", m.Transparency, 0.0)
+	}
+	if !tuples.FloatEqual(m.RefractiveIndex, 1.0) {
+		t.Errorf("got %f want %f", m.RefractiveIndex, 1.0)
+	}
+}
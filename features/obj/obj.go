@@ -0,0 +1,234 @@
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+	"strconv"
+	"strings"
+)
+
+// Parser : the result of reading a Wavefront OBJ file
+//
+// vertices, normals and texture coordinates are stored in the order they
+// appear, so OBJ index i (counting from 1) is element i-1. faces before any g
+// or o statement go into DefaultGroup, the rest into the named group in
+// Groups. texture coordinates are only collected and checked, the triangles
+// built from faces don't carry them
+type Parser struct {
+	Vertices      []tuples.Tuple
+	Normals       []tuples.Tuple
+	TextureCoords []tuples.Tuple
+	DefaultGroup  *shapes.Group
+	Groups        map[string]*shapes.Group
+	// IgnoredLines : line numbers of lines that were not understood
+	IgnoredLines []int
+	// groupNames : named groups in the order they first appeared
+	groupNames []string
+}
+
+// ParserNew : create an empty parser
+func ParserNew() Parser {
+	return Parser{
+		Vertices:      []tuples.Tuple{},
+		Normals:       []tuples.Tuple{},
+		TextureCoords: []tuples.Tuple{},
+		DefaultGroup:  shapes.GroupNew(),
+		Groups:        map[string]*shapes.Group{},
+		IgnoredLines:  []int{},
+		groupNames:    []string{},
+	}
+}
+
+// ParseObjFile : read OBJ data, returning an error naming the offending line
+//
+// faces with more than three vertices are triangulated as a fan around the
+// first vertex. faces with a normal on every vertex become smooth triangles
+func ParseObjFile(r io.Reader) (Parser, error) {
+	p := ParserNew()
+	current := p.DefaultGroup
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		// comments run from # to the end of the line
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		// blank lines carry no data, so don't report them
+		if len(fields) == 0 {
+			continue
+		}
+		var err error
+		switch fields[0] {
+		case "v":
+			var v tuples.Tuple
+			v, err = parseTuple(fields[1:], 3, 4)
+			p.Vertices = append(p.Vertices, tuples.PointNew(v.X, v.Y, v.Z))
+		case "vn":
+			var n tuples.Tuple
+			n, err = parseTuple(fields[1:], 3, 3)
+			p.Normals = append(p.Normals, tuples.VectorNew(n.X, n.Y, n.Z))
+		case "vt":
+			var t tuples.Tuple
+			t, err = parseTuple(fields[1:], 1, 3)
+			p.TextureCoords = append(p.TextureCoords, t)
+		case "f":
+			var triangles []shapes.Shape
+			triangles, err = p.parseFace(fields[1:])
+			current.AddChild(triangles...)
+		case "g", "o":
+			if len(fields) < 2 {
+				err = fmt.Errorf("missing group name")
+				break
+			}
+			name := strings.Join(fields[1:], " ")
+			if _, ok := p.Groups[name]; !ok {
+				p.Groups[name] = shapes.GroupNew()
+				p.groupNames = append(p.groupNames, name)
+			}
+			current = p.Groups[name]
+		default:
+			p.IgnoredLines = append(p.IgnoredLines, lineNumber)
+		}
+		if err != nil {
+			return p, fmt.Errorf("line %d: %s: %v", lineNumber, fields[0], err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return p, fmt.Errorf("line %d: %v", lineNumber+1, err)
+	}
+	return p, nil
+}
+
+// ParseObjPath : read an OBJ file from disk
+func ParseObjPath(path string) (Parser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ParserNew(), err
+	}
+	defer f.Close()
+	return ParseObjFile(f)
+}
+
+// ObjToGroup : gather every group of the parsed file into a single group
+//
+// each call hands out fresh copies of the parser's groups, so groups returned
+// by earlier calls keep their own parents. groups without faces are left out,
+// as their empty bounds would add nothing
+func ObjToGroup(p Parser) *shapes.Group {
+	g := shapes.GroupNew()
+	for _, group := range append([]*shapes.Group{p.DefaultGroup}, p.namedGroups()...) {
+		if len(group.Children) > 0 {
+			copied := shapes.GroupNew(group.Transform)
+			copied.AddChild(group.Children...)
+			g.AddChild(copied)
+		}
+	}
+	return g
+}
+
+// namedGroups : the named groups in the order they first appeared
+func (p Parser) namedGroups() []*shapes.Group {
+	groups := make([]*shapes.Group, len(p.groupNames))
+	for i, name := range p.groupNames {
+		groups[i] = p.Groups[name]
+	}
+	return groups
+}
+
+// ToGroup : gather every group of the parsed file into a single group
+func (p Parser) ToGroup() *shapes.Group {
+	return ObjToGroup(p)
+}
+
+// parseTuple : parse between min and max (at most 4) floats into the
+// components of a tuple
+//
+// missing components are left at zero
+func parseTuple(fields []string, min, max int) (tuples.Tuple, error) {
+	if min == max && len(fields) != min {
+		return tuples.Tuple{}, fmt.Errorf("expected %d numbers, got %d", min, len(fields))
+	}
+	if len(fields) < min || len(fields) > max {
+		return tuples.Tuple{}, fmt.Errorf("expected %d to %d numbers, got %d", min, max, len(fields))
+	}
+	values := [4]float64{}
+	for i, field := range fields {
+		f, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return tuples.Tuple{}, fmt.Errorf("invalid number %q", field)
+		}
+		values[i] = f
+	}
+	return tuples.TupleNew(values[0], values[1], values[2], values[3]), nil
+}
+
+// parseIndex : convert a 1-based (or negative, relative to the end) OBJ index
+// into a 0-based slice index
+func parseIndex(field string, count int, kind string) (int, error) {
+	i, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s index %q", kind, field)
+	}
+	if i < 0 {
+		i = count + i + 1
+	}
+	if i < 1 || i > count {
+		return 0, fmt.Errorf("%s index %s out of range, %d defined", kind, field, count)
+	}
+	return i - 1, nil
+}
+
+// parseFace : turn the vertices of a face into a fan of triangles
+//
+// each vertex is v, v/vt, v//vn or v/vt/vn
+func (p *Parser) parseFace(fields []string) ([]shapes.Shape, error) {
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("expected at least 3 vertices, got %d", len(fields))
+	}
+	vertices := make([]tuples.Tuple, len(fields))
+	normals := make([]tuples.Tuple, len(fields))
+	smooth := true
+	for i, field := range fields {
+		parts := strings.Split(field, "/")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid vertex %q", field)
+		}
+		v, err := parseIndex(parts[0], len(p.Vertices), "vertex")
+		if err != nil {
+			return nil, err
+		}
+		vertices[i] = p.Vertices[v]
+		if len(parts) > 1 && parts[1] != "" {
+			if _, err := parseIndex(parts[1], len(p.TextureCoords), "texture"); err != nil {
+				return nil, err
+			}
+		}
+		if len(parts) < 3 || parts[2] == "" {
+			smooth = false
+			continue
+		}
+		n, err := parseIndex(parts[2], len(p.Normals), "normal")
+		if err != nil {
+			return nil, err
+		}
+		normals[i] = p.Normals[n]
+	}
+	triangles := []shapes.Shape{}
+	for i := 1; i < len(vertices)-1; i++ {
+		if smooth {
+			triangles = append(triangles, shapes.SmoothTriangleNew(
+				vertices[0], vertices[i], vertices[i+1],
+				normals[0], normals[i], normals[i+1]))
+		} else {
+			triangles = append(triangles, shapes.TriangleNew(vertices[0], vertices[i], vertices[i+1]))
+		}
+	}
+	return triangles, nil
+}
@@ -0,0 +1,311 @@
+package obj
+
+import (
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+	"strings"
+	"testing"
+)
+
+func TestParseObjFileIgnoredLines(t *testing.T) {
+	gibberish := `There was a young lady named Bright
+who traveled much faster than light.
+
+She set out one day
+in a relative way,
+and came back the previous night.`
+	p, err := ParseObjFile(strings.NewReader(gibberish))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{1, 2, 4, 5, 6}
+	if len(p.IgnoredLines) != len(want) {
+		t.Fatalf("got %v want %v", p.IgnoredLines, want)
+	}
+	for i := range want {
+		if p.IgnoredLines[i] != want[i] {
+			t.Errorf("got %d want %d", p.IgnoredLines[i], want[i])
+		}
+	}
+}
+
+func TestParseObjFileVertices(t *testing.T) {
+	file := `v -1 1 0
+v -1.0000 0.5000 0.0000
+v 1 0 0
+v 1 1 0`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tuples.Tuple{
+		tuples.PointNew(-1, 1, 0),
+		tuples.PointNew(-1, 0.5, 0),
+		tuples.PointNew(1, 0, 0),
+		tuples.PointNew(1, 1, 0),
+	}
+	if len(p.Vertices) != len(want) {
+		t.Fatalf("got %d want %d", len(p.Vertices), len(want))
+	}
+	for i := range want {
+		if !p.Vertices[i].Equal(want[i]) {
+			t.Errorf("got %v want %v", p.Vertices[i], want[i])
+		}
+	}
+}
+
+func TestParseObjFileTriangleFaces(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+f 1 2 3
+f 1 3 4`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := p.DefaultGroup
+	if len(g.Children) != 2 {
+		t.Fatalf("got %d want %d", len(g.Children), 2)
+	}
+	t1 := g.Children[0].(shapes.Triangle)
+	t2 := g.Children[1].(shapes.Triangle)
+	if !t1.P1.Equal(p.Vertices[0]) || !t1.P2.Equal(p.Vertices[1]) || !t1.P3.Equal(p.Vertices[2]) {
+		t.Errorf("got %v want %v", t1, p.Vertices[0:3])
+	}
+	if !t2.P1.Equal(p.Vertices[0]) || !t2.P2.Equal(p.Vertices[2]) || !t2.P3.Equal(p.Vertices[3]) {
+		t.Errorf("got %v want %v", t2, p.Vertices)
+	}
+}
+
+func TestParseObjFilePolygon(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+v 0 2 0
+
+f 1 2 3 4 5`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := p.DefaultGroup
+	if len(g.Children) != 3 {
+		t.Fatalf("got %d want %d", len(g.Children), 3)
+	}
+	// every triangle in the fan shares the first vertex
+	for i, child := range g.Children {
+		tri := child.(shapes.Triangle)
+		if !tri.P1.Equal(p.Vertices[0]) || !tri.P2.Equal(p.Vertices[i+1]) || !tri.P3.Equal(p.Vertices[i+2]) {
+			t.Errorf("%d: got %v", i, tri)
+		}
+	}
+}
+
+func TestParseObjFileNamedGroups(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+
+g FirstGroup
+f 1 2 3
+o SecondGroup
+f 1 3 4`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.DefaultGroup.Children) != 0 {
+		t.Errorf("got %d want %d", len(p.DefaultGroup.Children), 0)
+	}
+	for _, name := range []string{"FirstGroup", "SecondGroup"} {
+		g, ok := p.Groups[name]
+		if !ok {
+			t.Fatalf("missing group %s", name)
+		}
+		if len(g.Children) != 1 {
+			t.Errorf("%s: got %d want %d", name, len(g.Children), 1)
+		}
+	}
+
+	// the default group has no faces, so only the named groups are added
+	g := p.ToGroup()
+	if len(g.Children) != 2 {
+		t.Fatalf("got %d want %d", len(g.Children), 2)
+	}
+	r := rays.RayNew(tuples.PointNew(0, 5, -5), tuples.VectorNew(0, 0, 1))
+	if g.Bounds().Intersects(r) {
+		t.Errorf("got a hit on a ray missing every face, bounds %v", g.Bounds())
+	}
+	second := g.Children[1].(*shapes.Group)
+	if len(second.Children) != 1 || second.Children[0].GetParent() != second {
+		t.Errorf("got %v want one triangle with parent %v", second.Children, second)
+	}
+}
+
+func TestParseObjFileToGroupTwice(t *testing.T) {
+	file := `v -1 1 0
+v -1 0 0
+v 1 0 0
+g FirstGroup
+f 1 2 3`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := p.ToGroup()
+	second := p.ToGroup()
+	for _, g := range []*shapes.Group{first, second} {
+		sub := g.Children[0].(*shapes.Group)
+		if sub.Parent != g {
+			t.Errorf("got parent %p want %p", sub.Parent, g)
+		}
+		if sub.Children[0].GetParent() != sub {
+			t.Errorf("got parent %p want %p", sub.Children[0].GetParent(), sub)
+		}
+	}
+}
+
+func TestParseObjFileNormalsAndTextures(t *testing.T) {
+	file := `vn 0 0 1
+vn 0.707 0 -0.707
+vn 1 2 3
+vt 0.5 0.25`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tuples.Tuple{
+		tuples.VectorNew(0, 0, 1),
+		tuples.VectorNew(0.707, 0, -0.707),
+		tuples.VectorNew(1, 2, 3),
+	}
+	if len(p.Normals) != len(want) {
+		t.Fatalf("got %d want %d", len(p.Normals), len(want))
+	}
+	for i := range want {
+		if !p.Normals[i].Equal(want[i]) {
+			t.Errorf("got %v want %v", p.Normals[i], want[i])
+		}
+	}
+	if len(p.TextureCoords) != 1 {
+		t.Fatalf("got %d want %d", len(p.TextureCoords), 1)
+	}
+	if !tuples.FloatEqual(p.TextureCoords[0].X, 0.5) || !tuples.FloatEqual(p.TextureCoords[0].Y, 0.25) {
+		t.Errorf("got %v want %v", p.TextureCoords[0], tuples.TupleNew(0.5, 0.25, 0, 0))
+	}
+}
+
+// texture coordinates may have one, two or three components
+func TestParseObjFileTextureCoordinates(t *testing.T) {
+	file := `vt 0.5
+vt 0.5 0.25
+vt 0.5 0.25 0.125`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tuples.Tuple{
+		tuples.TupleNew(0.5, 0, 0, 0),
+		tuples.TupleNew(0.5, 0.25, 0, 0),
+		tuples.TupleNew(0.5, 0.25, 0.125, 0),
+	}
+	if len(p.TextureCoords) != len(want) {
+		t.Fatalf("got %d want %d", len(p.TextureCoords), len(want))
+	}
+	for i := range want {
+		if !p.TextureCoords[i].Equal(want[i]) {
+			t.Errorf("got %v want %v", p.TextureCoords[i], want[i])
+		}
+	}
+}
+
+// comments can follow the data on a line, and aren't reported as ignored
+func TestParseObjFileComments(t *testing.T) {
+	file := `# a single triangle
+v -1 1 0 # top left
+v -1 0 0
+v 1 0 0#no space
+f 1 2 3 # the only face`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Vertices) != 3 {
+		t.Errorf("got %d want %d", len(p.Vertices), 3)
+	}
+	if len(p.DefaultGroup.Children) != 1 {
+		t.Errorf("got %d want %d", len(p.DefaultGroup.Children), 1)
+	}
+	if len(p.IgnoredLines) != 0 {
+		t.Errorf("got %v want %v", p.IgnoredLines, []int{})
+	}
+}
+
+func TestParseObjFileFacesWithNormals(t *testing.T) {
+	file := `v 0 1 0
+v -1 0 0
+v 1 0 0
+
+vn -1 0 0
+vn 1 0 0
+vn 0 1 0
+
+vt 0 0
+
+f 1//3 2//1 3//2
+f 1/1/3 2/1/1 3/1/2
+f -3//-1 -2//-3 -1//-2`
+	p, err := ParseObjFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := p.DefaultGroup
+	if len(g.Children) != 3 {
+		t.Fatalf("got %d want %d", len(g.Children), 3)
+	}
+	for i, child := range g.Children {
+		tri, ok := child.(shapes.SmoothTriangle)
+		if !ok {
+			t.Fatalf("%d: got %T want %T", i, child, shapes.SmoothTriangle{})
+		}
+		if !tri.P1.Equal(p.Vertices[0]) || !tri.P2.Equal(p.Vertices[1]) || !tri.P3.Equal(p.Vertices[2]) {
+			t.Errorf("%d: got %v want %v", i, tri, p.Vertices)
+		}
+		if !tri.N1.Equal(p.Normals[2]) || !tri.N2.Equal(p.Normals[0]) || !tri.N3.Equal(p.Normals[1]) {
+			t.Errorf("%d: got %v want %v", i, tri, p.Normals)
+		}
+	}
+}
+
+func TestParseObjFileErrors(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"v 1 2 3\nv 1 x 3", "line 2: v: invalid number \"x\""},
+		{"v 1 2", "line 1: v: expected 3 to 4 numbers, got 2"},
+		{"v 1 2 3\nv 1 2 3\n\nf 1 2 3", "line 4: f: vertex index 3 out of range, 2 defined"},
+		{"v 1 2 3\nf 1 1", "line 2: f: expected at least 3 vertices, got 2"},
+		{"v 1 2 3\nf 1//1 1 1", "line 2: f: normal index 1 out of range, 0 defined"},
+		{"g", "line 1: g: missing group name"},
+		{"vn 0 0 1 0", "line 1: vn: expected 3 numbers, got 4"},
+		{"vt", "line 1: vt: expected 1 to 3 numbers, got 0"},
+		{"vt 0 0 0 0", "line 1: vt: expected 1 to 3 numbers, got 4"},
+	}
+	for _, test := range tests {
+		_, err := ParseObjFile(strings.NewReader(test.file))
+		if err == nil {
+			t.Errorf("%q: got no error want %q", test.file, test.want)
+			continue
+		}
+		if err.Error() != test.want {
+			t.Errorf("%q: got %q want %q", test.file, err.Error(), test.want)
+		}
+	}
+}
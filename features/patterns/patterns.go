@@ -0,0 +1,137 @@
+package patterns
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Pattern interface
+//
+// patterns are evaluated in pattern space, so a pattern can be scaled, moved
+// or rotated independently of the object it is applied to
+type Pattern interface {
+	PatternAt(patternPoint tuples.Tuple) tuples.Tuple
+	GetTransform() *mat.Dense
+}
+
+// PatternAtObject : evaluate a pattern at an object space point
+func PatternAtObject(p Pattern, objectPoint tuples.Tuple) tuples.Tuple {
+	inverse := transformations.IdentityNew(4)
+	inverse.Inverse(p.GetTransform())
+	return p.PatternAt(objectPoint.Transform(inverse))
+}
+
+// patternTransform : pick the optional transform passed to a constructor
+func patternTransform(transform []*mat.Dense) *mat.Dense {
+	if len(transform) == 0 {
+		return transformations.IdentityNew(4)
+	}
+	return transform[0]
+}
+
+// Stripe : alternate between two colors as x changes
+type Stripe struct {
+	A, B      tuples.Tuple
+	Transform *mat.Dense
+}
+
+// StripeNew : stripe pattern constructor
+//
+// using variadic function to make transform optional
+func StripeNew(a, b tuples.Tuple, transform ...*mat.Dense) Stripe {
+	return Stripe{a, b, patternTransform(transform)}
+}
+
+// PatternAt : a when floor(x) is even, b otherwise
+func (s Stripe) PatternAt(patternPoint tuples.Tuple) tuples.Tuple {
+	if math.Mod(math.Floor(patternPoint.X), 2) == 0 {
+		return s.A
+	}
+	return s.B
+}
+
+// GetTransform : get the transform of the stripe pattern
+func (s Stripe) GetTransform() *mat.Dense {
+	return s.Transform
+}
+
+// Gradient : linearly blend from one color to another as x changes
+type Gradient struct {
+	A, B      tuples.Tuple
+	Transform *mat.Dense
+}
+
+// GradientNew : gradient pattern constructor
+//
+// using variadic function to make transform optional
+func GradientNew(a, b tuples.Tuple, transform ...*mat.Dense) Gradient {
+	return Gradient{a, b, patternTransform(transform)}
+}
+
+// PatternAt : blend a and b by the fractional part of x
+func (g Gradient) PatternAt(patternPoint tuples.Tuple) tuples.Tuple {
+	distance := g.B.Subtract(g.A)
+	fraction := patternPoint.X - math.Floor(patternPoint.X)
+	return g.A.Add(distance.ScalarMultiply(fraction))
+}
+
+// GetTransform : get the transform of the gradient pattern
+func (g Gradient) GetTransform() *mat.Dense {
+	return g.Transform
+}
+
+// Ring : alternate between two colors in concentric rings around the y axis
+type Ring struct {
+	A, B      tuples.Tuple
+	Transform *mat.Dense
+}
+
+// RingNew : ring pattern constructor
+//
+// using variadic function to make transform optional
+func RingNew(a, b tuples.Tuple, transform ...*mat.Dense) Ring {
+	return Ring{a, b, patternTransform(transform)}
+}
+
+// PatternAt : a when the floored distance from the y axis is even, b otherwise
+func (r Ring) PatternAt(patternPoint tuples.Tuple) tuples.Tuple {
+	distance := math.Sqrt(patternPoint.X*patternPoint.X + patternPoint.Z*patternPoint.Z)
+	if math.Mod(math.Floor(distance), 2) == 0 {
+		return r.A
+	}
+	return r.B
+}
+
+// GetTransform : get the transform of the ring pattern
+func (r Ring) GetTransform() *mat.Dense {
+	return r.Transform
+}
+
+// Checkers : alternate between two colors in unit cubes
+type Checkers struct {
+	A, B      tuples.Tuple
+	Transform *mat.Dense
+}
+
+// CheckersNew : 3D checkers pattern constructor
+//
+// using variadic function to make transform optional
+func CheckersNew(a, b tuples.Tuple, transform ...*mat.Dense) Checkers {
+	return Checkers{a, b, patternTransform(transform)}
+}
+
+// PatternAt : a when the sum of the floored components is even, b otherwise
+func (c Checkers) PatternAt(patternPoint tuples.Tuple) tuples.Tuple {
+	sum := math.Floor(patternPoint.X) + math.Floor(patternPoint.Y) + math.Floor(patternPoint.Z)
+	if math.Mod(sum, 2) == 0 {
+		return c.A
+	}
+	return c.B
+}
+
+// GetTransform : get the transform of the checkers pattern
+func (c Checkers) GetTransform() *mat.Dense {
+	return c.Transform
+}
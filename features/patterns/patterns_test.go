@@ -0,0 +1,115 @@
+package patterns
+
+import (
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+var black = tuples.ColorNew(0, 0, 0)
+var white = tuples.ColorNew(1, 1, 1)
+
+func TestStripe(t *testing.T) {
+	p := StripeNew(white, black)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		// constant in y
+		{tuples.PointNew(0, 1, 0), white},
+		{tuples.PointNew(0, 2, 0), white},
+		// constant in z
+		{tuples.PointNew(0, 0, 1), white},
+		{tuples.PointNew(0, 0, 2), white},
+		// alternates in x
+		{tuples.PointNew(0, 0, 0), white},
+		{tuples.PointNew(0.9, 0, 0), white},
+		{tuples.PointNew(1, 0, 0), black},
+		{tuples.PointNew(-0.1, 0, 0), black},
+		{tuples.PointNew(-1, 0, 0), black},
+		{tuples.PointNew(-1.1, 0, 0), white},
+	}
+	for _, test := range tests {
+		got := p.PatternAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	p := GradientNew(white, black)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(0, 0, 0), white},
+		{tuples.PointNew(0.25, 0, 0), tuples.ColorNew(0.75, 0.75, 0.75)},
+		{tuples.PointNew(0.5, 0, 0), tuples.ColorNew(0.5, 0.5, 0.5)},
+		{tuples.PointNew(0.75, 0, 0), tuples.ColorNew(0.25, 0.25, 0.25)},
+	}
+	for _, test := range tests {
+		got := p.PatternAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestRing(t *testing.T) {
+	p := RingNew(white, black)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(0, 0, 0), white},
+		{tuples.PointNew(1, 0, 0), black},
+		{tuples.PointNew(0, 0, 1), black},
+		// 0.708 = just slightly more than sqrt(2)/2
+		{tuples.PointNew(0.708, 0, 0.708), black},
+	}
+	for _, test := range tests {
+		got := p.PatternAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestCheckers(t *testing.T) {
+	p := CheckersNew(white, black)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		// repeats in x
+		{tuples.PointNew(0, 0, 0), white},
+		{tuples.PointNew(0.99, 0, 0), white},
+		{tuples.PointNew(1.01, 0, 0), black},
+		// repeats in y
+		{tuples.PointNew(0, 0.99, 0), white},
+		{tuples.PointNew(0, 1.01, 0), black},
+		// repeats in z
+		{tuples.PointNew(0, 0, 0.99), white},
+		{tuples.PointNew(0, 0, 1.01), black},
+	}
+	for _, test := range tests {
+		got := p.PatternAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestPatternAtObject(t *testing.T) {
+	p := StripeNew(white, black, transformations.ScalingNew(2, 2, 2))
+	got := PatternAtObject(p, tuples.PointNew(1.5, 0, 0))
+	if !got.Equal(white) {
+		t.Errorf("got %v want %v", got, white)
+	}
+	p = StripeNew(white, black, transformations.TranslationNew(0.5, 0, 0))
+	got = PatternAtObject(p, tuples.PointNew(1.5, 0, 0))
+	if !got.Equal(black) {
+		t.Errorf("got %v want %v", got, black)
+	}
+}
@@ -0,0 +1,482 @@
+package ply
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+	"strconv"
+	"strings"
+)
+
+// Parser : the result of reading a Stanford PLY file
+//
+// Normals, TextureCoords and Colors are either empty, or hold one entry for
+// each vertex. each face lists the 0-based indices of its vertices. elements
+// other than vertex and face are read and skipped. texture coordinates are
+// only collected, the triangles built from faces don't carry them
+type Parser struct {
+	Vertices      []tuples.Tuple
+	Normals       []tuples.Tuple
+	TextureCoords []tuples.Tuple
+	Colors        []tuples.Tuple
+	Faces         [][]int
+	// Comments : the text of the comment lines in the header
+	Comments []string
+}
+
+// ParserNew : create an empty parser
+func ParserNew() Parser {
+	return Parser{
+		Vertices:      []tuples.Tuple{},
+		Normals:       []tuples.Tuple{},
+		TextureCoords: []tuples.Tuple{},
+		Colors:        []tuples.Tuple{},
+		Faces:         [][]int{},
+		Comments:      []string{},
+	}
+}
+
+// property : one property of an element, as declared in the header
+//
+// list properties hold a count of countType, followed by that many values
+type property struct {
+	name      string
+	valueType string
+	countType string
+	list      bool
+}
+
+// element : a kind of record in the body, as declared in the header
+type element struct {
+	name       string
+	count      int
+	properties []property
+}
+
+// find : the position of the first property with one of the names, or -1
+func (e element) find(names ...string) int {
+	for _, name := range names {
+		for i, p := range e.properties {
+			if p.name == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// typeSizes : the size in bytes of each property type, under both of the
+// names the format allows
+var typeSizes = map[string]int{
+	"char": 1, "int8": 1, "uchar": 1, "uint8": 1,
+	"short": 2, "int16": 2, "ushort": 2, "uint16": 2,
+	"int": 4, "int32": 4, "uint": 4, "uint32": 4,
+	"float": 4, "float32": 4, "double": 8, "float64": 8,
+}
+
+// ParsePlyFile : read PLY data in any of the ascii, binary_little_endian and
+// binary_big_endian formats
+//
+// header errors name the offending line, body errors the offending element
+func ParsePlyFile(r io.Reader) (Parser, error) {
+	p := ParserNew()
+	br := bufio.NewReader(r)
+	format, elements, err := p.parseHeader(br)
+	if err != nil {
+		return p, err
+	}
+	var values valueReader
+	switch format {
+	case "ascii":
+		scanner := bufio.NewScanner(br)
+		scanner.Split(bufio.ScanWords)
+		values = &asciiReader{scanner}
+	case "binary_little_endian":
+		values = &binaryReader{r: br, order: binary.LittleEndian}
+	case "binary_big_endian":
+		values = &binaryReader{r: br, order: binary.BigEndian}
+	}
+	for _, e := range elements {
+		if err := p.parseElement(e, values); err != nil {
+			return p, err
+		}
+	}
+	// anything left over means the header doesn't describe the body
+	if err := values.finish(); err != nil {
+		return p, err
+	}
+	for i, face := range p.Faces {
+		for _, v := range face {
+			if v < 0 || v >= len(p.Vertices) {
+				return p, fmt.Errorf("face %d: vertex index %d out of range, %d defined", i, v, len(p.Vertices))
+			}
+		}
+	}
+	return p, nil
+}
+
+// ParsePlyPath : read a PLY file from disk
+func ParsePlyPath(path string) (Parser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return ParserNew(), err
+	}
+	defer f.Close()
+	return ParsePlyFile(f)
+}
+
+// PlyToGroup : turn the faces of the parsed file into a group of triangles
+//
+// faces with more than three vertices are triangulated as a fan around the
+// first vertex. if the file has normals the triangles are smooth, and if it
+// has colors each triangle takes the average color of its vertices. large
+// meshes should be passed to shapes.Divide before rendering
+func PlyToGroup(p Parser) *shapes.Group {
+	g := shapes.GroupNew()
+	triangles := []shapes.Shape{}
+	for _, face := range p.Faces {
+		for i := 1; i < len(face)-1; i++ {
+			a, b, c := face[0], face[i], face[i+1]
+			if len(p.Normals) > 0 {
+				t := shapes.SmoothTriangleNew(p.Vertices[a], p.Vertices[b], p.Vertices[c],
+					p.Normals[a], p.Normals[b], p.Normals[c])
+				if len(p.Colors) > 0 {
+					t.Material.Color = averageColor(p.Colors[a], p.Colors[b], p.Colors[c])
+				}
+				triangles = append(triangles, t)
+			} else {
+				t := shapes.TriangleNew(p.Vertices[a], p.Vertices[b], p.Vertices[c])
+				if len(p.Colors) > 0 {
+					t.Material.Color = averageColor(p.Colors[a], p.Colors[b], p.Colors[c])
+				}
+				triangles = append(triangles, t)
+			}
+		}
+	}
+	g.AddChild(triangles...)
+	return g
+}
+
+// ToGroup : turn the faces of the parsed file into a group of triangles
+func (p Parser) ToGroup() *shapes.Group {
+	return PlyToGroup(p)
+}
+
+func averageColor(a, b, c tuples.Tuple) tuples.Tuple {
+	return tuples.ColorScalarMultiply(tuples.ColorAdd(tuples.ColorAdd(a, b), c), 1.0/3)
+}
+
+// parseHeader : read the header up to end_header, returning the format and
+// the elements of the body in order
+func (p *Parser) parseHeader(br *bufio.Reader) (string, []element, error) {
+	format := ""
+	elements := []element{}
+	lineNumber := 0
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return "", nil, fmt.Errorf("line %d: missing end_header", lineNumber+1)
+			}
+			return "", nil, fmt.Errorf("line %d: %v", lineNumber+1, err)
+		}
+		lineNumber++
+		fields := strings.Fields(line)
+		if lineNumber == 1 {
+			if len(fields) != 1 || fields[0] != "ply" {
+				return "", nil, fmt.Errorf("line 1: not a PLY file")
+			}
+			continue
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "format":
+			if len(fields) != 3 {
+				err = fmt.Errorf("expected a format and version")
+			} else if fields[1] != "ascii" && fields[1] != "binary_little_endian" && fields[1] != "binary_big_endian" {
+				err = fmt.Errorf("unsupported format %q", fields[1])
+			}
+			if err == nil {
+				format = fields[1]
+			}
+		case "comment":
+			p.Comments = append(p.Comments, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "comment")))
+		case "obj_info":
+		case "element":
+			var e element
+			e, err = parseElementLine(fields[1:])
+			elements = append(elements, e)
+		case "property":
+			if len(elements) == 0 {
+				err = fmt.Errorf("property before any element")
+				break
+			}
+			var prop property
+			prop, err = parsePropertyLine(fields[1:])
+			last := &elements[len(elements)-1]
+			last.properties = append(last.properties, prop)
+		case "end_header":
+			if format == "" {
+				return "", nil, fmt.Errorf("line %d: missing format", lineNumber)
+			}
+			return format, elements, nil
+		default:
+			err = fmt.Errorf("unknown keyword")
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %s: %v", lineNumber, fields[0], err)
+		}
+	}
+}
+
+// parseElementLine : parse the name and count of an element
+func parseElementLine(fields []string) (element, error) {
+	if len(fields) != 2 {
+		return element{}, fmt.Errorf("expected a name and count")
+	}
+	count, err := strconv.Atoi(fields[1])
+	if err != nil || count < 0 {
+		return element{}, fmt.Errorf("invalid count %q", fields[1])
+	}
+	return element{name: fields[0], count: count}, nil
+}
+
+// parsePropertyLine : parse "type name" or "list countType valueType name"
+func parsePropertyLine(fields []string) (property, error) {
+	if len(fields) > 0 && fields[0] == "list" {
+		if len(fields) != 4 {
+			return property{}, fmt.Errorf("expected a count type, value type and name")
+		}
+		for _, t := range fields[1:3] {
+			if _, ok := typeSizes[t]; !ok {
+				return property{}, fmt.Errorf("unknown type %q", t)
+			}
+		}
+		return property{name: fields[3], valueType: fields[2], countType: fields[1], list: true}, nil
+	}
+	if len(fields) != 2 {
+		return property{}, fmt.Errorf("expected a type and name")
+	}
+	if _, ok := typeSizes[fields[0]]; !ok {
+		return property{}, fmt.Errorf("unknown type %q", fields[0])
+	}
+	return property{name: fields[1], valueType: fields[0]}, nil
+}
+
+// parseElement : read every record of an element from the body
+func (p *Parser) parseElement(e element, values valueReader) error {
+	scalars := make([]float64, len(e.properties))
+	indices := e.find("vertex_indices", "vertex_index")
+	switch e.name {
+	case "vertex":
+		for _, name := range []string{"x", "y", "z"} {
+			if e.find(name) < 0 {
+				return fmt.Errorf("vertex: missing property %q", name)
+			}
+		}
+	case "face":
+		if indices < 0 {
+			return fmt.Errorf("face: missing property %q", "vertex_indices")
+		}
+	}
+	for n := 0; n < e.count; n++ {
+		face := []int{}
+		for k, prop := range e.properties {
+			if !prop.list {
+				v, err := values.read(prop.valueType)
+				if err != nil {
+					return fmt.Errorf("%s %d: %s: %v", e.name, n, prop.name, err)
+				}
+				scalars[k] = v
+				continue
+			}
+			count, err := readWhole(values, prop.countType)
+			if err != nil {
+				return fmt.Errorf("%s %d: %s count: %v", e.name, n, prop.name, err)
+			}
+			for c := 0; c < count; c++ {
+				if k != indices {
+					if _, err := values.read(prop.valueType); err != nil {
+						return fmt.Errorf("%s %d: %s: %v", e.name, n, prop.name, err)
+					}
+					continue
+				}
+				v, err := readWhole(values, prop.valueType)
+				if err != nil {
+					return fmt.Errorf("%s %d: %s: %v", e.name, n, prop.name, err)
+				}
+				face = append(face, v)
+			}
+		}
+		switch e.name {
+		case "vertex":
+			p.addVertex(e, scalars)
+		case "face":
+			if len(face) < 3 {
+				return fmt.Errorf("face %d: expected at least 3 vertices, got %d", n, len(face))
+			}
+			p.Faces = append(p.Faces, face)
+		}
+	}
+	return nil
+}
+
+// addVertex : record the position, and any normal, texture coordinates and
+// color, of one vertex
+func (p *Parser) addVertex(e element, scalars []float64) {
+	get := func(names ...string) (float64, bool) {
+		if i := e.find(names...); i >= 0 {
+			return scalars[i], true
+		}
+		return 0, false
+	}
+	// parseElement has already checked the position is there
+	x, _ := get("x")
+	y, _ := get("y")
+	z, _ := get("z")
+	p.Vertices = append(p.Vertices, tuples.PointNew(x, y, z))
+	nx, okX := get("nx")
+	ny, okY := get("ny")
+	nz, okZ := get("nz")
+	if okX && okY && okZ {
+		p.Normals = append(p.Normals, tuples.VectorNew(nx, ny, nz))
+	}
+	u, okU := get("u", "s", "texture_u", "texture_s")
+	v, okV := get("v", "t", "texture_v", "texture_t")
+	if okU && okV {
+		p.TextureCoords = append(p.TextureCoords, tuples.TupleNew(u, v, 0, 0))
+	}
+	red := e.find("red", "diffuse_red")
+	green := e.find("green", "diffuse_green")
+	blue := e.find("blue", "diffuse_blue")
+	if red >= 0 && green >= 0 && blue >= 0 {
+		p.Colors = append(p.Colors, tuples.ColorNew(
+			colorChannel(e.properties[red], scalars[red]),
+			colorChannel(e.properties[green], scalars[green]),
+			colorChannel(e.properties[blue], scalars[blue])))
+	}
+}
+
+// channelMaximums : the value of a fully lit color channel for each integer
+// type
+var channelMaximums = map[string]float64{
+	"char": math.MaxInt8, "int8": math.MaxInt8, "uchar": math.MaxUint8, "uint8": math.MaxUint8,
+	"short": math.MaxInt16, "int16": math.MaxInt16, "ushort": math.MaxUint16, "uint16": math.MaxUint16,
+	"int": math.MaxInt32, "int32": math.MaxInt32, "uint": math.MaxUint32, "uint32": math.MaxUint32,
+}
+
+// colorChannel : scale a color channel to 0-1
+//
+// integer channels run from 0 to the largest value of their type, and
+// negative values of signed types are treated as 0. floating point channels
+// are already scaled
+func colorChannel(prop property, value float64) float64 {
+	maximum, ok := channelMaximums[prop.valueType]
+	if !ok {
+		return value
+	}
+	return tuples.FloatClamp(value/maximum, 0, 1)
+}
+
+// valueReader : reads the values of the body one at a time
+type valueReader interface {
+	read(valueType string) (float64, error)
+	// finish : check that nothing is left after the last value
+	finish() error
+}
+
+// readWhole : read a list count or vertex index, which must be a whole,
+// non-negative number
+//
+// ascii files can hold any number, and a float type can be declared for
+// either, so a value like 3.5 is rejected rather than cut down to 3
+func readWhole(values valueReader, valueType string) (int, error) {
+	v, err := values.read(valueType)
+	if err != nil {
+		return 0, err
+	}
+	if v < 0 || v != math.Trunc(v) || v > math.MaxInt32 {
+		return 0, fmt.Errorf("expected a whole number, got %v", v)
+	}
+	return int(v), nil
+}
+
+// asciiReader : reads values written as whitespace separated numbers
+type asciiReader struct {
+	scanner *bufio.Scanner
+}
+
+func (a *asciiReader) read(valueType string) (float64, error) {
+	if !a.scanner.Scan() {
+		if err := a.scanner.Err(); err != nil {
+			return 0, err
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+	token := a.scanner.Text()
+	v, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", token)
+	}
+	return v, nil
+}
+
+func (a *asciiReader) finish() error {
+	if a.scanner.Scan() {
+		return fmt.Errorf("unexpected %q after the last element", a.scanner.Text())
+	}
+	return a.scanner.Err()
+}
+
+// binaryReader : reads values packed in the given byte order
+type binaryReader struct {
+	r     io.Reader
+	order binary.ByteOrder
+	buf   [8]byte
+}
+
+func (b *binaryReader) read(valueType string) (float64, error) {
+	size := typeSizes[valueType]
+	buf := b.buf[:size]
+	if _, err := io.ReadFull(b.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	switch valueType {
+	case "char", "int8":
+		return float64(int8(buf[0])), nil
+	case "uchar", "uint8":
+		return float64(buf[0]), nil
+	case "short", "int16":
+		return float64(int16(b.order.Uint16(buf))), nil
+	case "ushort", "uint16":
+		return float64(b.order.Uint16(buf)), nil
+	case "int", "int32":
+		return float64(int32(b.order.Uint32(buf))), nil
+	case "uint", "uint32":
+		return float64(b.order.Uint32(buf)), nil
+	case "float", "float32":
+		return float64(math.Float32frombits(b.order.Uint32(buf))), nil
+	}
+	return math.Float64frombits(b.order.Uint64(buf)), nil
+}
+
+func (b *binaryReader) finish() error {
+	_, err := io.ReadFull(b.r, b.buf[:1])
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("unexpected data after the last element")
+}
@@ -0,0 +1,249 @@
+package ply
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/tuples"
+	"strings"
+	"testing"
+)
+
+const asciiSquare = `ply
+format ascii 1.0
+comment a unit square
+element vertex 4
+property float x
+property float y
+property float z
+property float nx
+property float ny
+property float nz
+property float s
+property float t
+property uchar red
+property uchar green
+property uchar blue
+element face 1
+property list uchar int vertex_indices
+element edge 1
+property int vertex1
+property int vertex2
+end_header
+0 0 0 0 0 1 0 0 255 0 0
+1 0 0 0 0 1 1 0 0 255 0
+1 1 0 0 0 1 1 1 0 0 255
+0 1 0 0 0 1 0 1 255 255 255
+4 0 1 2 3
+0 2
+`
+
+func TestParsePlyFileASCII(t *testing.T) {
+	p, err := ParsePlyFile(strings.NewReader(asciiSquare))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantVertices := []tuples.Tuple{
+		tuples.PointNew(0, 0, 0),
+		tuples.PointNew(1, 0, 0),
+		tuples.PointNew(1, 1, 0),
+		tuples.PointNew(0, 1, 0),
+	}
+	if len(p.Vertices) != len(wantVertices) {
+		t.Fatalf("got %d want %d", len(p.Vertices), len(wantVertices))
+	}
+	for i := range wantVertices {
+		if !p.Vertices[i].Equal(wantVertices[i]) {
+			t.Errorf("got %v want %v", p.Vertices[i], wantVertices[i])
+		}
+	}
+	if len(p.Normals) != 4 || !p.Normals[2].Equal(tuples.VectorNew(0, 0, 1)) {
+		t.Errorf("got %v want 4 normals of %v", p.Normals, tuples.VectorNew(0, 0, 1))
+	}
+	if len(p.TextureCoords) != 4 || !p.TextureCoords[1].Equal(tuples.TupleNew(1, 0, 0, 0)) {
+		t.Errorf("got %v want 4 texture coordinates", p.TextureCoords)
+	}
+	if len(p.Colors) != 4 || !p.Colors[1].Equal(tuples.ColorNew(0, 1, 0)) {
+		t.Errorf("got %v want 4 colors", p.Colors)
+	}
+	if len(p.Faces) != 1 || len(p.Faces[0]) != 4 {
+		t.Errorf("got %v want one face of 4 vertices", p.Faces)
+	}
+	if len(p.Comments) != 1 || p.Comments[0] != "a unit square" {
+		t.Errorf("got %q want %q", p.Comments, []string{"a unit square"})
+	}
+}
+
+// binaryPly : a single colored triangle, written in the given byte order
+func binaryPly(format string, order binary.ByteOrder) []byte {
+	var b bytes.Buffer
+	b.WriteString("ply\nformat " + format + " 1.0\n" +
+		"element vertex 3\nproperty float x\nproperty float y\nproperty double z\n" +
+		"property short u\nproperty ushort v\nproperty float red\nproperty float green\nproperty float blue\n" +
+		"element face 1\nproperty list uchar uint vertex_index\nproperty char flags\nend_header\n")
+	vertices := [][3]float64{{0, 1, 0}, {-1, 0, 0}, {1, 0, -0.5}}
+	for i, v := range vertices {
+		binary.Write(&b, order, float32(v[0]))
+		binary.Write(&b, order, float32(v[1]))
+		binary.Write(&b, order, v[2])
+		binary.Write(&b, order, int16(-i))
+		binary.Write(&b, order, uint16(i*1000))
+		binary.Write(&b, order, []float32{0.5, 0.25, float32(i)})
+	}
+	b.WriteByte(3)
+	binary.Write(&b, order, []uint32{0, 1, 2})
+	binary.Write(&b, order, int8(-1))
+	return b.Bytes()
+}
+
+func TestParsePlyFileBinary(t *testing.T) {
+	tests := []struct {
+		format string
+		order  binary.ByteOrder
+	}{
+		{"binary_little_endian", binary.LittleEndian},
+		{"binary_big_endian", binary.BigEndian},
+	}
+	for _, tt := range tests {
+		p, err := ParsePlyFile(bytes.NewReader(binaryPly(tt.format, tt.order)))
+		if err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		want := tuples.PointNew(1, 0, -0.5)
+		if len(p.Vertices) != 3 || !p.Vertices[2].Equal(want) {
+			t.Errorf("%s: got %v want %v last", tt.format, p.Vertices, want)
+		}
+		wantUV := tuples.TupleNew(-2, 2000, 0, 0)
+		if len(p.TextureCoords) != 3 || !p.TextureCoords[2].Equal(wantUV) {
+			t.Errorf("%s: got %v want %v last", tt.format, p.TextureCoords, wantUV)
+		}
+		wantColor := tuples.ColorNew(0.5, 0.25, 1)
+		if len(p.Colors) != 3 || !p.Colors[1].Equal(wantColor) {
+			t.Errorf("%s: got %v want %v second", tt.format, p.Colors, wantColor)
+		}
+		if len(p.Normals) != 0 {
+			t.Errorf("%s: got %d normals want %d", tt.format, len(p.Normals), 0)
+		}
+		if len(p.Faces) != 1 || p.Faces[0][2] != 2 {
+			t.Errorf("%s: got %v want %v", tt.format, p.Faces, [][]int{{0, 1, 2}})
+		}
+	}
+}
+
+func TestPlyToGroup(t *testing.T) {
+	p, err := ParsePlyFile(strings.NewReader(asciiSquare))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := p.ToGroup()
+	if len(g.Children) != 2 {
+		t.Fatalf("got %d want %d", len(g.Children), 2)
+	}
+	t1, ok := g.Children[0].(shapes.SmoothTriangle)
+	if !ok {
+		t.Fatalf("got %T want shapes.SmoothTriangle", g.Children[0])
+	}
+	if !t1.P1.Equal(p.Vertices[0]) || !t1.P2.Equal(p.Vertices[1]) || !t1.P3.Equal(p.Vertices[2]) {
+		t.Errorf("got %v %v %v", t1.P1, t1.P2, t1.P3)
+	}
+	want := tuples.ColorNew(1.0/3, 1.0/3, 1.0/3)
+	if !t1.Material.Color.Equal(want) {
+		t.Errorf("got %v want %v", t1.Material.Color, want)
+	}
+	t2 := g.Children[1].(shapes.SmoothTriangle)
+	if !t2.P1.Equal(p.Vertices[0]) || !t2.P2.Equal(p.Vertices[2]) || !t2.P3.Equal(p.Vertices[3]) {
+		t.Errorf("got %v %v %v", t2.P1, t2.P2, t2.P3)
+	}
+	// without normals the triangles are flat
+	p, err = ParsePlyFile(bytes.NewReader(binaryPly("binary_little_endian", binary.LittleEndian)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g = PlyToGroup(p)
+	if _, ok := g.Children[0].(shapes.Triangle); !ok || len(g.Children) != 1 {
+		t.Errorf("got %v want one shapes.Triangle", g.Children)
+	}
+}
+
+// binaryVertexHeader : the header of a binary file with one float vertex
+const binaryVertexHeader = "ply\nformat binary_little_endian 1.0\nelement vertex 1\n" +
+	"property float x\nproperty float y\nproperty float z\nend_header\n"
+
+// integer color channels are scaled by the largest value of their own type
+func TestParsePlyFileIntegerColors(t *testing.T) {
+	file := `ply
+format ascii 1.0
+element vertex 2
+property float x
+property float y
+property float z
+property ushort red
+property char green
+property uint blue
+end_header
+0 0 0 65535 127 4294967295
+0 0 0 32768 -5 0
+`
+	p, err := ParsePlyFile(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []tuples.Tuple{
+		tuples.ColorNew(1, 1, 1),
+		tuples.ColorNew(32768.0/65535, 0, 0),
+	}
+	if len(p.Colors) != len(want) {
+		t.Fatalf("got %d want %d", len(p.Colors), len(want))
+	}
+	for i := range want {
+		if !p.Colors[i].Equal(want[i]) {
+			t.Errorf("got %v want %v", p.Colors[i], want[i])
+		}
+	}
+}
+
+func TestParsePlyFileErrors(t *testing.T) {
+	header := "ply\nformat ascii 1.0\nelement vertex 3\nproperty float x\nproperty float y\nproperty float z\n" +
+		"element face 1\nproperty list uchar int vertex_indices\nend_header\n"
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"not ply", "obj\n", "line 1: not a PLY file"},
+		{"bad format", "ply\nformat binary 1.0\n", "line 2: format: unsupported format \"binary\""},
+		{"no format", "ply\nelement vertex 0\nend_header\n", "line 3: missing format"},
+		{"no end", "ply\nformat ascii 1.0\n", "line 3: missing end_header"},
+		{"bad type", "ply\nformat ascii 1.0\nelement vertex 1\nproperty float3 x\n", "line 4: property: unknown type \"float3\""},
+		{"orphan property", "ply\nformat ascii 1.0\nproperty float x\n", "line 3: property: property before any element"},
+		{"bad count", "ply\nformat ascii 1.0\nelement vertex many\n", "line 3: element: invalid count \"many\""},
+		{"unknown keyword", "ply\nformat ascii 1.0\nvertex 1 2 3\n", "line 3: vertex: unknown keyword"},
+		{"short body", header + "0 0 0\n1 0 0\n", "vertex 2: x: unexpected EOF"},
+		{"bad number", header + "0 0 0\n1 0 0\n1 one 0\n", "vertex 2: y: invalid number \"one\""},
+		{"small face", header + "0 0 0\n1 0 0\n1 1 0\n2 0 1\n", "face 0: expected at least 3 vertices, got 2"},
+		{"bad index", header + "0 0 0\n1 0 0\n1 1 0\n3 0 1 3\n", "face 0: vertex index 3 out of range, 3 defined"},
+		{"fractional count", header + "0 0 0\n1 0 0\n1 1 0\n3.5 0 1 2\n",
+			"face 0: vertex_indices count: expected a whole number, got 3.5"},
+		{"fractional index", header + "0 0 0\n1 0 0\n1 1 0\n3 0 1.5 2\n",
+			"face 0: vertex_indices: expected a whole number, got 1.5"},
+		{"negative index", header + "0 0 0\n1 0 0\n1 1 0\n3 0 -1 2\n",
+			"face 0: vertex_indices: expected a whole number, got -1"},
+		{"short binary body", binaryVertexHeader + "\x00\x00", "vertex 0: x: unexpected EOF"},
+		{"missing position", "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float z\nend_header\n0 0\n",
+			"vertex: missing property \"y\""},
+		{"missing indices", "ply\nformat ascii 1.0\nelement face 1\nproperty list uchar int indices\nend_header\n3 0 1 2\n",
+			"face: missing property \"vertex_indices\""},
+		{"trailing ascii", header + "0 0 0\n1 0 0\n1 1 0\n3 0 1 2\n7\n", "unexpected \"7\" after the last element"},
+		{"trailing binary", binaryVertexHeader + strings.Repeat("\x00", 12) + "\x01",
+			"unexpected data after the last element"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePlyFile(strings.NewReader(tt.data))
+		if err == nil {
+			t.Fatalf("%s: got no error want %q", tt.name, tt.want)
+		}
+		if err.Error() != tt.want {
+			t.Errorf("%s: got %q want %q", tt.name, err.Error(), tt.want)
+		}
+	}
+}
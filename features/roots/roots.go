@@ -0,0 +1,274 @@
+package roots
+
+import (
+	"math"
+	"sarim-tracer/features/tuples"
+	"sort"
+)
+
+// newtonIterations : how many Newton steps are used to polish each root
+var newtonIterations = 4
+
+// touchTolerance : how close to zero, relative to the size of its terms, a
+// polynomial must be for SolvePolynomialBetween to treat a point as a root
+var touchTolerance = 1e-12
+
+// SolveLinear : find the root of a*x + b = 0
+//
+// returns no roots if a is zero
+func SolveLinear(a, b float64) []float64 {
+	if a == 0 {
+		return []float64{}
+	}
+	return []float64{-b / a}
+}
+
+// SolveQuadratic : find the real roots of a*x^2 + b*x + c = 0, in ascending
+// order
+//
+// a double root is returned twice. the textbook formula subtracts two nearly
+// equal numbers when b*b is much larger than 4*a*c, so instead the root with
+// no cancellation is found first and the other is derived from the product of
+// the roots, c/a
+func SolveQuadratic(a, b, c float64) []float64 {
+	if a == 0 {
+		return SolveLinear(b, c)
+	}
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return []float64{}
+	}
+	if discriminant == 0 {
+		x := -b / (2 * a)
+		return []float64{x, x}
+	}
+	q := -0.5 * (b + math.Copysign(math.Sqrt(discriminant), b))
+	x0 := q / a
+	x1 := c / q
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	return []float64{x0, x1}
+}
+
+// SolveCubic : find the real roots of a*x^3 + b*x^2 + c*x + d = 0, in
+// ascending order
+//
+// the cubic is reduced to a depressed cubic t^3 + p*t + q = 0, solved with
+// Cardano's formula when it has one real root and the trigonometric method
+// when it has three, then each root is polished with Newton's method. a
+// repeated root is returned once for each time it repeats, like the
+// quadratic's double root
+func SolveCubic(a, b, c, d float64) []float64 {
+	if a == 0 {
+		return SolveQuadratic(b, c, d)
+	}
+	// normalize to x^3 + A*x^2 + B*x + C
+	A, B, C := b/a, c/a, d/a
+	// substitute x = t - A/3 to eliminate the quadratic term
+	shift := A / 3
+	p := B - A*A/3
+	q := 2*A*A*A/27 - A*B/3 + C
+	ts := []float64{}
+	discriminant := q*q/4 + p*p*p/27
+	if math.Abs(discriminant) < 1e-14*math.Max(1, math.Abs(p*p*p)) {
+		// a repeated root, a triple root when p is also zero
+		if p == 0 {
+			ts = append(ts, 0, 0, 0)
+		} else {
+			ts = append(ts, 3*q/p, -3*q/(2*p), -3*q/(2*p))
+		}
+	} else if discriminant > 0 {
+		// one real root, pick the sign that avoids cancellation
+		u := math.Cbrt(-q/2 - math.Copysign(math.Sqrt(discriminant), q))
+		v := 0.0
+		if u != 0 {
+			v = -p / (3 * u)
+		}
+		ts = append(ts, u+v)
+	} else {
+		// three real roots
+		m := 2 * math.Sqrt(-p/3)
+		theta := math.Acos(tuples.FloatClamp(3*q/(p*m), -1, 1)) / 3
+		for k := 0.0; k < 3; k++ {
+			ts = append(ts, m*math.Cos(theta-2*math.Pi*k/3))
+		}
+	}
+	xs := make([]float64, len(ts))
+	for i, t := range ts {
+		xs[i] = polish([]float64{1, A, B, C}, t-shift)
+	}
+	sort.Float64s(xs)
+	return xs
+}
+
+// SolveQuartic : find the real roots of a*x^4 + b*x^3 + c*x^2 + d*x + e = 0,
+// in ascending order
+//
+// uses Ferrari's method: the quartic is reduced to a depressed quartic, which
+// factors into two quadratics using a root of the resolvent cubic. each root
+// is polished with Newton's method, and repeated roots are returned once for
+// each time they repeat
+func SolveQuartic(a, b, c, d, e float64) []float64 {
+	if a == 0 {
+		return SolveCubic(b, c, d, e)
+	}
+	// normalize to x^4 + A*x^3 + B*x^2 + C*x + D
+	A, B, C, D := b/a, c/a, d/a, e/a
+	// substitute x = y - A/4 to eliminate the cubic term
+	shift := A / 4
+	A2 := A * A
+	p := B - 3*A2/8
+	q := C - A*B/2 + A2*A/8
+	r := D - A*C/4 + A2*B/16 - 3*A2*A2/256
+	ys := []float64{}
+	if math.Abs(q) < 1e-12 {
+		// biquadratic, solve as a quadratic in y^2
+		for _, z := range SolveQuadratic(1, p, r) {
+			if z > 0 {
+				ys = append(ys, -math.Sqrt(z), math.Sqrt(z))
+			} else if z > -1e-12 {
+				// y^2 = 0 is a double root
+				ys = append(ys, 0, 0)
+			}
+		}
+	} else {
+		// the largest root of the resolvent cubic is always positive when q
+		// is non-zero
+		zs := SolveCubic(1, 2*p, p*p-4*r, -q*q)
+		z := zs[len(zs)-1]
+		if z <= 0 {
+			return []float64{}
+		}
+		s := math.Sqrt(z)
+		ys = append(ys, SolveQuadratic(1, s, (p+z)/2-q/(2*s))...)
+		ys = append(ys, SolveQuadratic(1, -s, (p+z)/2+q/(2*s))...)
+	}
+	xs := make([]float64, len(ys))
+	for i, y := range ys {
+		xs[i] = polish([]float64{1, A, B, C, D}, y-shift)
+	}
+	sort.Float64s(xs)
+	return xs
+}
+
+// SolvePolynomialBetween : find the real roots of a polynomial of any degree
+// between low and high, in ascending order
+//
+// coefficients are ordered from the highest power down. the roots of the
+// derivative split the range into pieces where the polynomial only rises or
+// only falls, so each piece holds at most one root, which is found by
+// bisection. unlike sampling, two roots can't hide between samples. a root
+// where the polynomial touches zero without crossing it lies on one of the
+// piece ends, so an end is a root when the polynomial is within rounding
+// error of zero there. each root is returned once
+func SolvePolynomialBetween(coefficients []float64, low, high float64) []float64 {
+	// leading zeros don't change the polynomial, but would make the derivative
+	// look like it has a higher degree
+	for len(coefficients) > 0 && coefficients[0] == 0 {
+		coefficients = coefficients[1:]
+	}
+	degree := len(coefficients) - 1
+	if degree < 1 || low > high {
+		return []float64{}
+	}
+	bounds := []float64{low}
+	if degree > 1 {
+		derivative := make([]float64, degree)
+		for i := range derivative {
+			derivative[i] = coefficients[i] * float64(degree-i)
+		}
+		bounds = append(bounds, SolvePolynomialBetween(derivative, low, high)...)
+	}
+	bounds = append(bounds, high)
+	values := make([]float64, len(bounds))
+	touches := make([]bool, len(bounds))
+	for i, x := range bounds {
+		values[i] = evaluate(coefficients, x)
+		touches[i] = math.Abs(values[i]) <= touchTolerance*evaluateMagnitude(coefficients, x)
+	}
+	xs := []float64{}
+	for i, x := range bounds {
+		if touches[i] && (len(xs) == 0 || xs[len(xs)-1] != x) {
+			xs = append(xs, x)
+		}
+		if i+1 == len(bounds) || touches[i] || touches[i+1] {
+			continue
+		}
+		if (values[i] < 0) != (values[i+1] < 0) {
+			xs = append(xs, bisect(coefficients, x, bounds[i+1], values[i]))
+		}
+	}
+	return xs
+}
+
+// bisect : narrow down the root between a and b, where the polynomial changes
+// sign, until the interval can't be halved any further
+func bisect(coefficients []float64, a, b, va float64) float64 {
+	for i := 0; i < 200; i++ {
+		mid := a + (b-a)/2
+		if mid <= a || mid >= b {
+			break
+		}
+		if vm := evaluate(coefficients, mid); (vm < 0) == (va < 0) {
+			a, va = mid, vm
+		} else {
+			b = mid
+		}
+	}
+	return a + (b-a)/2
+}
+
+// polish : refine a root with a few steps of Newton's method
+//
+// coefficients are ordered from the highest power down. the refined root is
+// only kept if it is at least as good as the original
+func polish(coefficients []float64, x float64) float64 {
+	best, bestValue := x, math.Abs(evaluate(coefficients, x))
+	for i := 0; i < newtonIterations; i++ {
+		value, derivative := evaluateWithDerivative(coefficients, x)
+		if derivative == 0 {
+			break
+		}
+		x -= value / derivative
+		if v := math.Abs(evaluate(coefficients, x)); v < bestValue {
+			best, bestValue = x, v
+		}
+	}
+	return best
+}
+
+// evaluate : evaluate a polynomial with Horner's method
+func evaluate(coefficients []float64, x float64) float64 {
+	value, _ := evaluateWithDerivative(coefficients, x)
+	return value
+}
+
+// evaluateMagnitude : the scale of a polynomial's value around x, the sum of
+// the absolute values of its terms
+//
+// |x| is raised to at least 1, so that near zero the scale follows the
+// coefficients rather than the tiny powers of x
+func evaluateMagnitude(coefficients []float64, x float64) float64 {
+	return evaluate(absolute(coefficients), math.Max(1, math.Abs(x)))
+}
+
+// absolute : the absolute value of every coefficient
+func absolute(coefficients []float64) []float64 {
+	result := make([]float64, len(coefficients))
+	for i, c := range coefficients {
+		result[i] = math.Abs(c)
+	}
+	return result
+}
+
+// evaluateWithDerivative : evaluate a polynomial and its derivative with
+// Horner's method
+func evaluateWithDerivative(coefficients []float64, x float64) (float64, float64) {
+	value, derivative := 0.0, 0.0
+	for _, c := range coefficients {
+		derivative = derivative*x + value
+		value = value*x + c
+	}
+	return value, derivative
+}
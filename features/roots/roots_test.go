@@ -0,0 +1,153 @@
+package roots
+
+import (
+	"math"
+	"testing"
+)
+
+// rootsEqual : compare root lists to the given tolerance
+func rootsEqual(got, want []float64, tolerance float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSolveLinear(t *testing.T) {
+	got := SolveLinear(2, -4)
+	want := []float64{2}
+	if !rootsEqual(got, want, 1e-12) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = SolveLinear(0, 1)
+	if len(got) != 0 {
+		t.Errorf("got %v want %v", got, []float64{})
+	}
+}
+
+func TestSolveQuadratic(t *testing.T) {
+	tests := []struct {
+		a, b, c float64
+		want    []float64
+	}{
+		{1, -3, 2, []float64{1, 2}},
+		{1, 2, 1, []float64{-1, -1}},
+		{1, 0, 1, []float64{}},
+		{0, 2, -4, []float64{2}},
+		{-2, 0, 8, []float64{-2, 2}},
+	}
+	for _, test := range tests {
+		got := SolveQuadratic(test.a, test.b, test.c)
+		if !rootsEqual(got, test.want, 1e-12) {
+			t.Errorf("%v %v %v: got %v want %v", test.a, test.b, test.c, got, test.want)
+		}
+	}
+}
+
+func TestSolveQuadraticPrecision(t *testing.T) {
+	// the textbook formula loses the small root entirely here
+	got := SolveQuadratic(1, -1e8, 1)
+	if math.Abs(got[0]-1e-8)/1e-8 > 1e-9 {
+		t.Errorf("got %v want %v", got[0], 1e-8)
+	}
+	if math.Abs(got[1]-1e8)/1e8 > 1e-9 {
+		t.Errorf("got %v want %v", got[1], 1e8)
+	}
+}
+
+func TestSolveCubic(t *testing.T) {
+	tests := []struct {
+		a, b, c, d float64
+		want       []float64
+	}{
+		// (x - 1)(x - 2)(x - 3)
+		{1, -6, 11, -6, []float64{1, 2, 3}},
+		// (x - 2)(x^2 + 1)
+		{1, -2, 1, -2, []float64{2}},
+		// x^3
+		{1, 0, 0, 0, []float64{0, 0, 0}},
+		// (x - 1)^3, a triple root away from the origin
+		{1, -3, 3, -1, []float64{1, 1, 1}},
+		// (x - 1)^2 (x + 2)
+		{1, 0, -3, 2, []float64{-2, 1, 1}},
+		// 2 (x + 1)(x - 0.5)(x - 4)
+		{2, -7, -5, 4, []float64{-1, 0.5, 4}},
+		// falls back to a quadratic
+		{0, 1, -3, 2, []float64{1, 2}},
+	}
+	for _, test := range tests {
+		got := SolveCubic(test.a, test.b, test.c, test.d)
+		if !rootsEqual(got, test.want, 1e-6) {
+			t.Errorf("%v %v %v %v: got %v want %v", test.a, test.b, test.c, test.d, got, test.want)
+		}
+	}
+}
+
+func TestSolveQuartic(t *testing.T) {
+	tests := []struct {
+		a, b, c, d, e float64
+		want          []float64
+	}{
+		// (x - 1)(x - 2)(x - 3)(x - 4)
+		{1, -10, 35, -50, 24, []float64{1, 2, 3, 4}},
+		// (x^2 - 1)(x^2 - 4), biquadratic
+		{1, 0, -5, 0, 4, []float64{-2, -1, 1, 2}},
+		// x^2 (x^2 - 1), biquadratic with a double root at zero
+		{1, 0, -1, 0, 0, []float64{-1, 0, 0, 1}},
+		// (x^2 + 1)(x^2 + 4), no real roots
+		{1, 0, 5, 0, 4, []float64{}},
+		// (x - 1)(x + 2)(x^2 + 1)
+		{1, 1, -1, 1, -2, []float64{-2, 1}},
+		// 3 (x + 0.5)(x - 0.25)(x - 10)(x - 20)
+		{3, -89.25, 577.125, 161.25, -75, []float64{-0.5, 0.25, 10, 20}},
+		// (x - 1)^2 (x - 3)^2, a ray grazing a torus
+		{1, -8, 22, -24, 9, []float64{1, 1, 3, 3}},
+		// falls back to a cubic
+		{0, 1, -6, 11, -6, []float64{1, 2, 3}},
+	}
+	for _, test := range tests {
+		got := SolveQuartic(test.a, test.b, test.c, test.d, test.e)
+		if !rootsEqual(got, test.want, 1e-6) {
+			t.Errorf("%v: got %v want %v", test, got, test.want)
+		}
+	}
+}
+
+func TestSolvePolynomialBetween(t *testing.T) {
+	tests := []struct {
+		name         string
+		coefficients []float64
+		low, high    float64
+		want         []float64
+	}{
+		{"linear", []float64{2, -1}, -10, 10, []float64{0.5}},
+		{"quadratic", []float64{1, 0, -4}, -10, 10, []float64{-2, 2}},
+		{"outside the range", []float64{1, 0, -4}, 0, 1, []float64{}},
+		{"part of the range", []float64{1, 0, -4}, 0, 10, []float64{2}},
+		// (x - 1)(x - 2)(x - 3)(x - 4)(x - 5)(x - 6)
+		{"sextic", []float64{1, -21, 175, -735, 1624, -1764, 720}, 0, 10, []float64{1, 2, 3, 4, 5, 6}},
+		// (x^2 - 1e-8)(x^2 + 1), two roots far closer together than any
+		// reasonable sampling
+		{"close roots", []float64{1, 0, 1 - 1e-8, 0, -1e-8}, -5, 5, []float64{-1e-4, 1e-4}},
+		{"leading zeros", []float64{0, 0, 1, -3}, -10, 10, []float64{3}},
+		{"constant", []float64{5}, -10, 10, []float64{}},
+		{"touching", []float64{1, 0, 0}, -1, 1, []float64{0}},
+		// (x - 0.5)^2
+		{"touching off center", []float64{1, -1, 0.25}, 0, 1, []float64{0.5}},
+		// x^2 (x - 2)
+		{"touching and crossing", []float64{1, -2, 0, 0}, -1, 3, []float64{0, 2}},
+		{"touching at the end", []float64{1, -2, 1}, 0, 1, []float64{1}},
+		{"crossing at the end", []float64{1, -1}, 0, 1, []float64{1}},
+	}
+	for _, tt := range tests {
+		got := SolvePolynomialBetween(tt.coefficients, tt.low, tt.high)
+		if !rootsEqual(got, tt.want, 1e-9) {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package sdf
+
+import (
+	"math"
+	"sarim-tracer/features/tuples"
+)
+
+// Func : a signed distance function
+//
+// returns the distance from a point to the nearest surface, negative inside
+// the shape. sphere tracing relies on the distance never overestimating how
+// far away the surface is
+type Func func(p tuples.Tuple) float64
+
+// Sphere : a sphere of the given radius around the origin
+func Sphere(radius float64) Func {
+	return func(p tuples.Tuple) float64 {
+		return length(p.X, p.Y, p.Z) - radius
+	}
+}
+
+// Box : a box around the origin, extending by halfExtents along each axis
+func Box(halfExtents tuples.Tuple) Func {
+	return func(p tuples.Tuple) float64 {
+		qx := math.Abs(p.X) - halfExtents.X
+		qy := math.Abs(p.Y) - halfExtents.Y
+		qz := math.Abs(p.Z) - halfExtents.Z
+		// distance from outside, plus the (negative) distance from inside
+		outside := length(math.Max(qx, 0), math.Max(qy, 0), math.Max(qz, 0))
+		inside := math.Min(math.Max(qx, math.Max(qy, qz)), 0)
+		return outside + inside
+	}
+}
+
+// RoundedBox : a box with its edges and corners rounded off by radius
+//
+// the outer extents of the box are still halfExtents
+func RoundedBox(halfExtents tuples.Tuple, radius float64) Func {
+	inner := Box(tuples.VectorNew(halfExtents.X-radius, halfExtents.Y-radius, halfExtents.Z-radius))
+	return func(p tuples.Tuple) float64 {
+		return inner(p) - radius
+	}
+}
+
+// Capsule : a cylinder from a to b with hemispherical ends
+func Capsule(a, b tuples.Tuple, radius float64) Func {
+	ba := b.Subtract(a)
+	return func(p tuples.Tuple) float64 {
+		pa := p.Subtract(a)
+		// the closest point on the segment, as a fraction of the way to b
+		h := tuples.FloatClamp(pa.DotProduct(ba)/ba.DotProduct(ba), 0, 1)
+		return pa.Subtract(ba.ScalarMultiply(h)).Magnitude() - radius
+	}
+}
+
+// Torus : a ring around the y axis, like shapes.Torus
+func Torus(majorRadius, minorRadius float64) Func {
+	return func(p tuples.Tuple) float64 {
+		qx := math.Sqrt(p.X*p.X+p.Z*p.Z) - majorRadius
+		return math.Sqrt(qx*qx+p.Y*p.Y) - minorRadius
+	}
+}
+
+// Union : the space inside either shape
+func Union(a, b Func) Func {
+	return func(p tuples.Tuple) float64 {
+		return math.Min(a(p), b(p))
+	}
+}
+
+// SmoothUnion : the union of two shapes, blended together where they are
+// closer than k
+func SmoothUnion(a, b Func, k float64) Func {
+	return func(p tuples.Tuple) float64 {
+		da, db := a(p), b(p)
+		h := tuples.FloatClamp(0.5+0.5*(db-da)/k, 0, 1)
+		return db + (da-db)*h - k*h*(1-h)
+	}
+}
+
+// Subtraction : the space inside a but not inside b
+func Subtraction(a, b Func) Func {
+	return func(p tuples.Tuple) float64 {
+		return math.Max(a(p), -b(p))
+	}
+}
+
+// Repeat : copies of a shape repeated forever, one per cell of the given size
+//
+// the shape should fit inside a single cell around the origin. an axis with a
+// period of zero is not repeated
+func Repeat(f Func, period tuples.Tuple) Func {
+	return func(p tuples.Tuple) float64 {
+		return f(tuples.PointNew(wrap(p.X, period.X), wrap(p.Y, period.Y), wrap(p.Z, period.Z)))
+	}
+}
+
+// Twist : rotate a shape around the y axis by k radians per unit of height
+//
+// twisting stretches space, so the result can overestimate the distance.
+// march twisted shapes with a smaller step scale
+func Twist(f Func, k float64) Func {
+	return func(p tuples.Tuple) float64 {
+		c, s := math.Cos(k*p.Y), math.Sin(k*p.Y)
+		return f(tuples.PointNew(c*p.X-s*p.Z, p.Y, s*p.X+c*p.Z))
+	}
+}
+
+// wrap : move x into the cell of the given size centered on zero
+func wrap(x, period float64) float64 {
+	if period == 0 {
+		return x
+	}
+	return x - period*math.Round(x/period)
+}
+
+func length(x, y, z float64) float64 {
+	return math.Sqrt(x*x + y*y + z*z)
+}
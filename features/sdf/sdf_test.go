@@ -0,0 +1,77 @@
+package sdf
+
+import (
+	"math"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestPrimitives(t *testing.T) {
+	tests := []struct {
+		name string
+		f    Func
+		p    tuples.Tuple
+		want float64
+	}{
+		{"sphere outside", Sphere(1), tuples.PointNew(0, 3, 0), 2},
+		{"sphere inside", Sphere(1), tuples.PointNew(0, 0, 0), -1},
+		{"box face", Box(tuples.VectorNew(1, 2, 3)), tuples.PointNew(3, 0, 0), 2},
+		{"box corner", Box(tuples.VectorNew(1, 1, 1)), tuples.PointNew(2, 2, 1), math.Sqrt2},
+		{"box inside", Box(tuples.VectorNew(1, 2, 3)), tuples.PointNew(0, 0, 0), -1},
+		{"rounded box face", RoundedBox(tuples.VectorNew(1, 1, 1), 0.25), tuples.PointNew(2, 0, 0), 1},
+		{"rounded box corner", RoundedBox(tuples.VectorNew(1, 1, 1), 0.25), tuples.PointNew(1, 1, 1),
+			math.Sqrt(3*0.25*0.25) - 0.25},
+		{"capsule side", Capsule(tuples.PointNew(0, -1, 0), tuples.PointNew(0, 1, 0), 0.5), tuples.PointNew(2, 0, 0), 1.5},
+		{"capsule end", Capsule(tuples.PointNew(0, -1, 0), tuples.PointNew(0, 1, 0), 0.5), tuples.PointNew(0, 3, 0), 1.5},
+		{"torus tube", Torus(2, 0.5), tuples.PointNew(2, 0, 0), -0.5},
+		{"torus hole", Torus(2, 0.5), tuples.PointNew(0, 0, 0), 1.5},
+	}
+	for _, tt := range tests {
+		got := tt.f(tt.p)
+		if !tuples.FloatEqual(got, tt.want) {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	a := Sphere(1)
+	b := Box(tuples.VectorNew(0.5, 0.5, 0.5))
+	tests := []struct {
+		name string
+		f    Func
+		p    tuples.Tuple
+		want float64
+	}{
+		{"union", Union(a, Sphere(0.5)), tuples.PointNew(0, 2, 0), 1},
+		{"subtraction removes the middle", Subtraction(a, b), tuples.PointNew(0, 0, 0), 0.5},
+		{"subtraction keeps the rest", Subtraction(a, b), tuples.PointNew(0, 0.75, 0), -0.25},
+		{"repeat", Repeat(a, tuples.VectorNew(4, 0, 0)), tuples.PointNew(8.5, 0, 0), -0.5},
+		{"repeat skips zero period", Repeat(a, tuples.VectorNew(4, 0, 0)), tuples.PointNew(0, 4, 0), 3},
+		{"twist", Twist(Box(tuples.VectorNew(2, 1, 0.5)), math.Pi), tuples.PointNew(0, 0.5, 1.5), -0.5},
+	}
+	for _, tt := range tests {
+		got := tt.f(tt.p)
+		if !tuples.FloatEqual(got, tt.want) {
+			t.Errorf("%s: got %v want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSmoothUnion(t *testing.T) {
+	a := Sphere(1)
+	b := Sphere(1)
+	p := tuples.PointNew(3, 0, 0)
+	// far from both shapes, the blend makes no difference
+	got := SmoothUnion(a, Sphere(0.5), 0.1)(p)
+	want := 2.0
+	if !tuples.FloatEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	// where the shapes are equally close, the blend pulls the surface out
+	got = SmoothUnion(a, b, 0.5)(p)
+	want = 2 - 0.125
+	if !tuples.FloatEqual(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
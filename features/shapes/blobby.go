@@ -0,0 +1,210 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/roots"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"sort"
+)
+
+// Metaball : one center of a Blobby
+//
+// the ball adds Weight * (1 - d^2/Radius^2)^3 to the field at distance d from
+// Center, falling smoothly to nothing at Radius. a negative weight carves
+// into other balls
+type Metaball struct {
+	Center tuples.Tuple
+	Radius float64
+	Weight float64
+}
+
+// MetaballNew : metaball constructor
+func MetaballNew(center tuples.Tuple, radius, weight float64) Metaball {
+	return Metaball{center, radius, weight}
+}
+
+// alongRay : the ball's contribution along a ray as a polynomial in t, with
+// coefficients from the highest power down
+//
+// inside the ball 1 - d^2/R^2 is a quadratic in t, so its cube has degree 6.
+// the polynomial only holds while the ray is inside the ball's radius
+func (m Metaball) alongRay(r rays.Ray) []float64 {
+	ballToRay := r.Origin.Subtract(m.Center)
+	r2 := m.Radius * m.Radius
+	// 1 - d^2/R^2 = a*t^2 + b*t + c
+	a := -r.Direction.DotProduct(r.Direction) / r2
+	b := -2 * r.Direction.DotProduct(ballToRay) / r2
+	c := 1 - ballToRay.DotProduct(ballToRay)/r2
+	squared := []float64{a * a, 2 * a * b, b*b + 2*a*c, 2 * b * c, c * c}
+	cubed := make([]float64, 7)
+	for i, s := range squared {
+		cubed[i] += m.Weight * s * a
+		cubed[i+1] += m.Weight * s * b
+		cubed[i+2] += m.Weight * s * c
+	}
+	return cubed
+}
+
+// gradientAt : the gradient of the ball's contribution at a point
+func (m Metaball) gradientAt(p tuples.Tuple) tuples.Tuple {
+	offset := p.Subtract(m.Center)
+	r2 := m.Radius * m.Radius
+	falloff := 1 - offset.DotProduct(offset)/r2
+	if falloff <= 0 {
+		return tuples.VectorNew(0, 0, 0)
+	}
+	return offset.ScalarMultiply(-6 * m.Weight * falloff * falloff / r2)
+}
+
+// Blobby : a Shape, an implicit surface around a set of metaballs
+//
+// the surface is where the sum of every ball's field equals Threshold, so
+// balls close together merge smoothly. the threshold should be above zero.
+// blobbies are handled by pointer, because the list of balls cannot be
+// compared
+type Blobby struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Balls     []Metaball
+	Threshold float64
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// BlobbyNew : blobby constructor, with no balls
+//
+// using variadic function to make transform optional
+func BlobbyNew(threshold float64, transform ...*mat.Dense) *Blobby {
+	b := &Blobby{
+		Transform: transformations.IdentityNew(4),
+		Material:  materials.MaterialNew(),
+		Balls:     []Metaball{},
+		Threshold: threshold,
+	}
+	if len(transform) > 0 {
+		b.Transform = transform[0]
+	}
+	b.id = shapeIDNew()
+	b.inverse = inverseCacheNew(b.Transform)
+	return b
+}
+
+// AddBall : add metaballs to the blobby
+func (b *Blobby) AddBall(balls ...Metaball) {
+	b.Balls = append(b.Balls, balls...)
+}
+
+// Intersect blobby with ray
+//
+// the ray is cut where it enters and leaves each ball's radius. between those
+// cuts the same balls contribute, so the field along the ray is a polynomial,
+// and its roots are found exactly rather than by sampling
+func (b *Blobby) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(b.inverseTransform())
+	intersections := []Intersection{}
+	cuts := []float64{}
+	for _, ball := range b.Balls {
+		ballToRay := r.Origin.Subtract(ball.Center)
+		ts := roots.SolveQuadratic(
+			r.Direction.DotProduct(r.Direction),
+			2*r.Direction.DotProduct(ballToRay),
+			ballToRay.DotProduct(ballToRay)-ball.Radius*ball.Radius)
+		if len(ts) == 2 && ts[0] < ts[1] {
+			cuts = append(cuts, ts...)
+		}
+	}
+	sort.Float64s(cuts)
+	for i := 1; i < len(cuts); i++ {
+		low, high := cuts[i-1], cuts[i]
+		if low == high {
+			continue
+		}
+		// measure t from the middle of the stretch, so the coefficients stay
+		// small for rays starting far away
+		middle := (low + high) / 2
+		local := rays.RayNew(r.Position(middle), r.Direction)
+		field := make([]float64, 7)
+		field[6] = -b.Threshold
+		active := false
+		for _, ball := range b.Balls {
+			offset := local.Origin.Subtract(ball.Center)
+			if offset.DotProduct(offset) >= ball.Radius*ball.Radius {
+				continue
+			}
+			for k, c := range ball.alongRay(local) {
+				field[k] += c
+			}
+			active = active || ball.Weight > 0
+		}
+		// only balls with positive weight can lift the field to the threshold
+		if !active {
+			continue
+		}
+		for _, t := range roots.SolvePolynomialBetween(field, low-middle, high-middle) {
+			// a root on a cut is found by the stretches on both sides of it
+			if n := len(intersections); n > 0 && tuples.FloatEqual(intersections[n-1].IntersectionValue, middle+t) {
+				continue
+			}
+			intersections = append(intersections, IntersectionNew(middle+t, b))
+		}
+	}
+	return intersections
+}
+
+// NormalAt : the field grows towards the centers, so the normal points
+// against its gradient
+func (b *Blobby) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(b, worldPoint)
+	gradient := tuples.VectorNew(0, 0, 0)
+	for _, ball := range b.Balls {
+		gradient = gradient.Add(ball.gradientAt(p))
+	}
+	return NormalToWorld(b, gradient.Negate())
+}
+
+// Bounds : the object space bounds of the blobby, the reach of every ball that
+// adds to the field
+func (b *Blobby) Bounds() BoundingBox {
+	box := BoundingBoxNew()
+	for _, ball := range b.Balls {
+		if ball.Weight <= 0 {
+			continue
+		}
+		reach := tuples.VectorNew(ball.Radius, ball.Radius, ball.Radius)
+		box = box.AddPoint(ball.Center.Subtract(reach)).AddPoint(ball.Center.Add(reach))
+	}
+	return box
+}
+
+// GetMaterial : get the material of the blobby
+func (b *Blobby) GetMaterial() materials.Material {
+	return b.Material
+}
+
+// GetTransform : get the transform of the blobby
+func (b *Blobby) GetTransform() *mat.Dense {
+	return b.Transform
+}
+
+func (b *Blobby) inverseTransform() *mat.Dense {
+	return b.inverse.of(b.Transform)
+}
+
+func (b *Blobby) getID() uint64 {
+	return b.id
+}
+
+// GetParent : get the group or CSG containing the blobby, if any
+func (b *Blobby) GetParent() Shape {
+	return b.Parent
+}
+
+func (b *Blobby) setParent(parent Shape) Shape {
+	b.Parent = parent
+	return b
+}
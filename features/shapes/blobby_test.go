@@ -0,0 +1,151 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+// a lone ball with a threshold of 1/8 has its surface where
+// 1 - d^2/R^2 = 1/2, so a radius 2 ball has a surface radius of sqrt(2)
+func singleBlobby() *Blobby {
+	b := BlobbyNew(0.125)
+	b.AddBall(MetaballNew(tuples.PointNew(0, 0, 0), 2, 1))
+	return b
+}
+
+func TestBlobbyIntersectSingleBall(t *testing.T) {
+	b := singleBlobby()
+	tests := []struct {
+		origin tuples.Tuple
+		dir    tuples.Tuple
+		want   []float64
+	}{
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1), []float64{5 - math.Sqrt2, 5 + math.Sqrt2}},
+		{tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1), []float64{-math.Sqrt2, math.Sqrt2}},
+		{tuples.PointNew(0, 1.5, -5), tuples.VectorNew(0, 0, 1), []float64{}},
+	}
+	for _, tt := range tests {
+		xs := b.Intersect(rays.RayNew(tt.origin, tt.dir))
+		if len(xs) != len(tt.want) {
+			t.Fatalf("got %d want %d", len(xs), len(tt.want))
+		}
+		for i, x := range xs {
+			if !tuples.FloatEqual(x.IntersectionValue, tt.want[i]) {
+				t.Errorf("got %v want %v", x.IntersectionValue, tt.want[i])
+			}
+			if x.Shape != Shape(b) {
+				t.Errorf("got %v want %v", x.Shape, b)
+			}
+		}
+	}
+}
+
+// a ray grazing the surface is inside it for a tiny stretch, far shorter than
+// any sensible sample spacing, and must still hit it twice
+func TestBlobbyIntersectGrazing(t *testing.T) {
+	b := singleBlobby()
+	y := math.Sqrt2 - 0.00001
+	// a small ball further along moves where the ray's stretches are cut, so
+	// the graze isn't in the middle of one
+	b.AddBall(MetaballNew(tuples.PointNew(0, y, 1), 0.5, 0.001))
+	xs := b.Intersect(rays.RayNew(tuples.PointNew(0, y, -5), tuples.VectorNew(0, 0, 1)))
+	half := math.Sqrt(2 - y*y)
+	want := []float64{5 - half, 5 + half}
+	if len(xs) != len(want) {
+		t.Fatalf("got %d want %d", len(xs), len(want))
+	}
+	for i, x := range xs {
+		if !tuples.FloatEqual(x.IntersectionValue, want[i]) {
+			t.Errorf("got %v want %v", x.IntersectionValue, want[i])
+		}
+	}
+}
+
+func TestBlobbyIntersectTangent(t *testing.T) {
+	b := singleBlobby()
+	xs := b.Intersect(rays.RayNew(tuples.PointNew(0, math.Sqrt2, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 5) {
+		t.Errorf("got %v want %v", xs[0].IntersectionValue, 5)
+	}
+}
+
+// two balls too far apart to touch on their own merge into one blob
+func TestBlobbyIntersectMerged(t *testing.T) {
+	b := BlobbyNew(0.5)
+	b.AddBall(MetaballNew(tuples.PointNew(-1, 0, 0), 2, 1), MetaballNew(tuples.PointNew(1, 0, 0), 2, 1))
+	xs := b.Intersect(rays.RayNew(tuples.PointNew(-5, 0, 0), tuples.VectorNew(1, 0, 0)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue+xs[1].IntersectionValue, 10) {
+		t.Errorf("got %v and %v, want them symmetric about %v", xs[0].IntersectionValue, xs[1].IntersectionValue, 5)
+	}
+	// the middle, between the balls, is inside
+	xs = b.Intersect(rays.RayNew(tuples.PointNew(0, -5, 0), tuples.VectorNew(0, 1, 0)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+}
+
+// a negative ball hollows out the middle, so the ray crosses four surfaces
+func TestBlobbyIntersectNegativeBall(t *testing.T) {
+	b := singleBlobby()
+	b.AddBall(MetaballNew(tuples.PointNew(0, 0, 0), 1, -1))
+	xs := b.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 4 {
+		t.Fatalf("got %d want %d", len(xs), 4)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 5-math.Sqrt2) ||
+		!tuples.FloatEqual(xs[1].IntersectionValue+xs[2].IntersectionValue, 10) {
+		t.Errorf("got %v", xs)
+	}
+}
+
+func TestBlobbyNormalAt(t *testing.T) {
+	b := singleBlobby()
+	v := math.Sqrt2 / math.Sqrt(3)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(math.Sqrt2, 0, 0), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(0, 0, -math.Sqrt2), tuples.VectorNew(0, 0, -1)},
+		{tuples.PointNew(v, v, v), tuples.VectorNew(1/math.Sqrt(3), 1/math.Sqrt(3), 1/math.Sqrt(3))},
+	}
+	for _, tt := range tests {
+		got := b.NormalAt(tt.point)
+		if !got.Equal(tt.want) {
+			t.Errorf("got %v want %v", got, tt.want)
+		}
+	}
+}
+
+func TestTransformedBlobby(t *testing.T) {
+	b := singleBlobby()
+	b.Transform = transformations.TranslationNew(10, 0, 0)
+	xs := b.Intersect(rays.RayNew(tuples.PointNew(10, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	got := b.NormalAt(tuples.PointNew(10, math.Sqrt2, 0))
+	want := tuples.VectorNew(0, 1, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestBlobbyBounds(t *testing.T) {
+	b := singleBlobby()
+	b.AddBall(MetaballNew(tuples.PointNew(3, 0, 0), 1, 1), MetaballNew(tuples.PointNew(0, 5, 0), 1, -1))
+	got := b.Bounds()
+	want := BoundingBoxNew(tuples.PointNew(-2, -2, -2), tuples.PointNew(4, 2, 2))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/tuples"
+)
+
+// BoundingBox : an axis-aligned box, used to skip shapes a ray cannot hit
+//
+// a new box is empty (min at +infinity, max at -infinity), and grows as
+// points and other boxes are added to it
+type BoundingBox struct {
+	Min, Max tuples.Tuple
+}
+
+// BoundingBoxNew : bounding box constructor
+//
+// using variadic function to make the corners optional, creating an empty box
+func BoundingBoxNew(corners ...tuples.Tuple) BoundingBox {
+	b := BoundingBox{
+		tuples.PointNew(math.Inf(1), math.Inf(1), math.Inf(1)),
+		tuples.PointNew(math.Inf(-1), math.Inf(-1), math.Inf(-1)),
+	}
+	for _, corner := range corners {
+		b = b.AddPoint(corner)
+	}
+	return b
+}
+
+// AddPoint : grow the box to contain a point
+func (b BoundingBox) AddPoint(p tuples.Tuple) BoundingBox {
+	b.Min = tuples.PointNew(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z))
+	b.Max = tuples.PointNew(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z))
+	return b
+}
+
+// AddBox : grow the box to contain another box
+//
+// adding an empty box leaves the box unchanged
+func (b BoundingBox) AddBox(other BoundingBox) BoundingBox {
+	if other.IsEmpty() {
+		return b
+	}
+	return b.AddPoint(other.Min).AddPoint(other.Max)
+}
+
+// IsEmpty : check if the box contains no points at all
+func (b BoundingBox) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// ContainsPoint : check if a point lies inside the box, or on its surface
+func (b BoundingBox) ContainsPoint(p tuples.Tuple) bool {
+	return b.Min.X <= p.X && p.X <= b.Max.X &&
+		b.Min.Y <= p.Y && p.Y <= b.Max.Y &&
+		b.Min.Z <= p.Z && p.Z <= b.Max.Z
+}
+
+// ContainsBox : check if another box lies entirely inside the box
+func (b BoundingBox) ContainsBox(other BoundingBox) bool {
+	return b.ContainsPoint(other.Min) && b.ContainsPoint(other.Max)
+}
+
+// Transform : find the box containing this box after a transform
+//
+// all eight corners are transformed, and a new box is grown around them.
+// infinite components stay infinite, instead of turning into NaN. an empty
+// box stays empty, as its corners would otherwise span every axis
+func (b BoundingBox) Transform(transform *mat.Dense) BoundingBox {
+	if b.IsEmpty() {
+		return b
+	}
+	result := BoundingBoxNew()
+	for _, x := range []float64{b.Min.X, b.Max.X} {
+		for _, y := range []float64{b.Min.Y, b.Max.Y} {
+			for _, z := range []float64{b.Min.Z, b.Max.Z} {
+				corner := transformCorner(transform, [3]float64{x, y, z})
+				result = result.AddBox(corner)
+			}
+		}
+	}
+	return result
+}
+
+// transformCorner : transform a corner of a bounding box that may be infinite
+//
+// zero entries of the matrix are skipped, so 0 * infinity does not poison a
+// component. a component that mixes +infinity and -infinity could be anywhere,
+// so it spans the whole axis
+func transformCorner(transform *mat.Dense, corner [3]float64) BoundingBox {
+	min := [3]float64{}
+	max := [3]float64{}
+	for i := 0; i < 3; i++ {
+		sum := transform.At(i, 3)
+		for j := 0; j < 3; j++ {
+			if m := transform.At(i, j); m != 0 {
+				sum += m * corner[j]
+			}
+		}
+		min[i], max[i] = sum, sum
+		if math.IsNaN(sum) {
+			min[i], max[i] = math.Inf(-1), math.Inf(1)
+		}
+	}
+	return BoundingBox{
+		tuples.PointNew(min[0], min[1], min[2]),
+		tuples.PointNew(max[0], max[1], max[2]),
+	}
+}
+
+// Intersects : check if a ray hits the box
+//
+// hits behind the ray's origin count too, because refraction needs every
+// intersection along the ray. an empty box is never hit
+func (b BoundingBox) Intersects(r rays.Ray) bool {
+	if b.IsEmpty() {
+		return false
+	}
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, b.Min.X, b.Max.X)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, b.Min.Y, b.Max.Y)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, b.Min.Z, b.Max.Z)
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	return tmin <= tmax
+}
+
+// Split : cut the box in half across its longest axis
+func (b BoundingBox) Split() (BoundingBox, BoundingBox) {
+	dx, dy, dz := b.Max.X-b.Min.X, b.Max.Y-b.Min.Y, b.Max.Z-b.Min.Z
+	greatest := math.Max(dx, math.Max(dy, dz))
+	x0, y0, z0 := b.Min.X, b.Min.Y, b.Min.Z
+	x1, y1, z1 := b.Max.X, b.Max.Y, b.Max.Z
+	if greatest == dx {
+		x0 = x0 + dx/2
+		x1 = x0
+	} else if greatest == dy {
+		y0 = y0 + dy/2
+		y1 = y0
+	} else {
+		z0 = z0 + dz/2
+		z1 = z0
+	}
+	midMin := tuples.PointNew(x0, y0, z0)
+	midMax := tuples.PointNew(x1, y1, z1)
+	return BoundingBoxNew(b.Min, midMax), BoundingBoxNew(midMin, b.Max)
+}
+
+// ParentSpaceBounds : the bounds of a shape in the space of its parent
+func ParentSpaceBounds(s Shape) BoundingBox {
+	return s.Bounds().Transform(s.GetTransform())
+}
@@ -0,0 +1,330 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestBoundingBoxNew(t *testing.T) {
+	b := BoundingBoxNew()
+	if !math.IsInf(b.Min.X, 1) || !math.IsInf(b.Max.X, -1) {
+		t.Errorf("got %v want an empty box", b)
+	}
+	b = BoundingBoxNew(tuples.PointNew(-1, -2, -3), tuples.PointNew(3, 2, 1))
+	if !b.Min.Equal(tuples.PointNew(-1, -2, -3)) || !b.Max.Equal(tuples.PointNew(3, 2, 1)) {
+		t.Errorf("got %v want %v", b, BoundingBox{tuples.PointNew(-1, -2, -3), tuples.PointNew(3, 2, 1)})
+	}
+}
+
+func TestBoundingBoxAdd(t *testing.T) {
+	b := BoundingBoxNew().AddPoint(tuples.PointNew(-5, 2, 0)).AddPoint(tuples.PointNew(7, 0, -3))
+	if !b.Min.Equal(tuples.PointNew(-5, 0, -3)) || !b.Max.Equal(tuples.PointNew(7, 2, 0)) {
+		t.Errorf("got %v", b)
+	}
+	b1 := BoundingBoxNew(tuples.PointNew(-5, -2, 0), tuples.PointNew(7, 4, 4))
+	b2 := BoundingBoxNew(tuples.PointNew(8, -7, -2), tuples.PointNew(14, 2, 8))
+	b = b1.AddBox(b2)
+	if !b.Min.Equal(tuples.PointNew(-5, -7, -2)) || !b.Max.Equal(tuples.PointNew(14, 4, 8)) {
+		t.Errorf("got %v", b)
+	}
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	b := BoundingBoxNew(tuples.PointNew(5, -2, 0), tuples.PointNew(11, 4, 7))
+	points := []struct {
+		point tuples.Tuple
+		want  bool
+	}{
+		{tuples.PointNew(5, -2, 0), true},
+		{tuples.PointNew(11, 4, 7), true},
+		{tuples.PointNew(8, 1, 3), true},
+		{tuples.PointNew(3, 0, 3), false},
+		{tuples.PointNew(8, -4, 3), false},
+		{tuples.PointNew(8, 1, -1), false},
+		{tuples.PointNew(13, 1, 3), false},
+	}
+	for _, test := range points {
+		if got := b.ContainsPoint(test.point); got != test.want {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+	boxes := []struct {
+		min, max tuples.Tuple
+		want     bool
+	}{
+		{tuples.PointNew(5, -2, 0), tuples.PointNew(11, 4, 7), true},
+		{tuples.PointNew(6, -1, 1), tuples.PointNew(10, 3, 6), true},
+		{tuples.PointNew(4, -3, -1), tuples.PointNew(10, 3, 6), false},
+		{tuples.PointNew(6, -1, 1), tuples.PointNew(12, 5, 8), false},
+	}
+	for _, test := range boxes {
+		if got := b.ContainsBox(BoundingBoxNew(test.min, test.max)); got != test.want {
+			t.Errorf("%v %v: got %v want %v", test.min, test.max, got, test.want)
+		}
+	}
+}
+
+func TestBoundingBoxTransform(t *testing.T) {
+	b := BoundingBoxNew(tuples.PointNew(-1, -1, -1), tuples.PointNew(1, 1, 1))
+	got := b.Transform(transformations.ChainTransform(
+		transformations.RotationXNew(math.Pi/4),
+		transformations.RotationYNew(math.Pi/4)))
+	if !got.Min.Equal(tuples.PointNew(-1.41421, -1.70711, -1.70711)) {
+		t.Errorf("got %v want %v", got.Min, tuples.PointNew(-1.41421, -1.70711, -1.70711))
+	}
+	if !got.Max.Equal(tuples.PointNew(1.41421, 1.70711, 1.70711)) {
+		t.Errorf("got %v want %v", got.Max, tuples.PointNew(1.41421, 1.70711, 1.70711))
+	}
+}
+
+func TestBoundingBoxTransformInfinite(t *testing.T) {
+	got := ParentSpaceBounds(PlaneNew(transformations.TranslationNew(0, 2, 0)))
+	if !math.IsInf(got.Min.X, -1) || !math.IsInf(got.Max.Z, 1) {
+		t.Errorf("got %v want an infinite box", got)
+	}
+	if !tuples.FloatEqual(got.Min.Y, 2) || !tuples.FloatEqual(got.Max.Y, 2) {
+		t.Errorf("got %v want y = 2", got)
+	}
+}
+
+func TestBoundingBoxTransformEmpty(t *testing.T) {
+	got := BoundingBoxNew().Transform(transformations.RotationYNew(math.Pi / 4))
+	if !got.IsEmpty() {
+		t.Errorf("got %v want an empty box", got)
+	}
+}
+
+func TestShapeBounds(t *testing.T) {
+	cyl := CylinderNew()
+	cyl.Minimum, cyl.Maximum = -5, 3
+	cone := ConeNew()
+	cone.Minimum, cone.Maximum = -5, 3
+	tests := []struct {
+		name     string
+		shape    Shape
+		min, max tuples.Tuple
+	}{
+		{"sphere", SphereNew(), tuples.PointNew(-1, -1, -1), tuples.PointNew(1, 1, 1)},
+		{"cube", CubeNew(), tuples.PointNew(-1, -1, -1), tuples.PointNew(1, 1, 1)},
+		{"cylinder", cyl, tuples.PointNew(-1, -5, -1), tuples.PointNew(1, 3, 1)},
+		{"cone", cone, tuples.PointNew(-5, -5, -5), tuples.PointNew(5, 3, 5)},
+		{"triangle", TriangleNew(tuples.PointNew(-3, 7, 2), tuples.PointNew(6, 2, -4), tuples.PointNew(2, -1, -1)),
+			tuples.PointNew(-3, -1, -4), tuples.PointNew(6, 7, 2)},
+	}
+	for _, test := range tests {
+		got := test.shape.Bounds()
+		if !got.Min.Equal(test.min) || !got.Max.Equal(test.max) {
+			t.Errorf("%s: got %v want %v %v", test.name, got, test.min, test.max)
+		}
+	}
+}
+
+func TestGroupBounds(t *testing.T) {
+	s := SphereNew(transformations.ChainTransform(
+		transformations.TranslationNew(2, 5, -3),
+		transformations.ScalingNew(2, 2, 2)))
+	c := CylinderNew(transformations.ChainTransform(
+		transformations.TranslationNew(-4, -1, 4),
+		transformations.ScalingNew(0.5, 1, 0.5)))
+	c.Minimum, c.Maximum = -2, 2
+	g := GroupNew()
+	g.AddChild(s, c)
+	got := g.Bounds()
+	if !got.Min.Equal(tuples.PointNew(-4.5, -3, -5)) || !got.Max.Equal(tuples.PointNew(4, 7, 4.5)) {
+		t.Errorf("got %v", got)
+	}
+	// adding to a nested group updates the cached bounds of its parents
+	inner := GroupNew()
+	g.AddChild(inner)
+	inner.AddChild(SphereNew(transformations.TranslationNew(10, 0, 0)))
+	got = g.Bounds()
+	if !got.Max.Equal(tuples.PointNew(11, 7, 4.5)) {
+		t.Errorf("got %v want %v", got.Max, tuples.PointNew(11, 7, 4.5))
+	}
+}
+
+func TestGroupBoundsEmptySubgroup(t *testing.T) {
+	g := GroupNew()
+	g.AddChild(GroupNew(transformations.TranslationNew(1, 2, 3)), SphereNew())
+	got := g.Bounds()
+	if !got.Min.Equal(tuples.PointNew(-1, -1, -1)) || !got.Max.Equal(tuples.PointNew(1, 1, 1)) {
+		t.Errorf("got %v", got)
+	}
+	empty := GroupNew()
+	empty.AddChild(GroupNew())
+	if got := empty.Bounds(); !got.IsEmpty() {
+		t.Errorf("got %v want an empty box", got)
+	}
+	r := rays.RayNew(tuples.PointNew(0, 5, -5), tuples.VectorNew(0, 0, 1))
+	if g.Bounds().Intersects(r) || empty.Bounds().Intersects(r) {
+		t.Errorf("got a hit on a ray missing every child")
+	}
+}
+
+func TestCSGBounds(t *testing.T) {
+	c := CSGNew(CSGDifference, SphereNew(), SphereNew(transformations.TranslationNew(2, 3, 4)))
+	got := c.Bounds()
+	if !got.Min.Equal(tuples.PointNew(-1, -1, -1)) || !got.Max.Equal(tuples.PointNew(3, 4, 5)) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestBoundingBoxIntersects(t *testing.T) {
+	b := BoundingBoxNew(tuples.PointNew(5, -2, 0), tuples.PointNew(11, 4, 7))
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		want      bool
+	}{
+		{tuples.PointNew(15, 1, 2), tuples.VectorNew(-1, 0, 0), true},
+		{tuples.PointNew(-5, -1, 4), tuples.VectorNew(1, 0, 0), true},
+		{tuples.PointNew(7, 6, 5), tuples.VectorNew(0, -1, 0), true},
+		{tuples.PointNew(9, -5, 6), tuples.VectorNew(0, 1, 0), true},
+		{tuples.PointNew(8, 2, 12), tuples.VectorNew(0, 0, -1), true},
+		{tuples.PointNew(6, 0, -5), tuples.VectorNew(0, 0, 1), true},
+		{tuples.PointNew(8, 1, 3.5), tuples.VectorNew(0, 0, 1), true},
+		{tuples.PointNew(9, -1, -8), tuples.VectorNew(2, 4, 6), false},
+		{tuples.PointNew(8, 3, -4), tuples.VectorNew(6, 2, 4), false},
+		{tuples.PointNew(9, -1, -2), tuples.VectorNew(4, 6, 2), false},
+		{tuples.PointNew(4, 0, 9), tuples.VectorNew(0, 0, -1), false},
+		{tuples.PointNew(8, 6, -1), tuples.VectorNew(0, -1, 0), false},
+		{tuples.PointNew(12, 5, 4), tuples.VectorNew(-1, 0, 0), false},
+		// starting on the plane of a face and running along it
+		{tuples.PointNew(5, 1, -5), tuples.VectorNew(0, 0, 1), true},
+		{tuples.PointNew(11, 4, 12), tuples.VectorNew(0, 0, -1), true},
+		{tuples.PointNew(5, 5, -5), tuples.VectorNew(0, 0, 1), false},
+	}
+	for _, test := range tests {
+		r := rays.RayNew(test.origin, test.direction.Normalize())
+		if got := b.Intersects(r); got != test.want {
+			t.Errorf("%v: got %v want %v", test.origin, got, test.want)
+		}
+	}
+}
+
+func TestBoundingBoxSplit(t *testing.T) {
+	tests := []struct {
+		min, max          tuples.Tuple
+		leftMax, rightMin tuples.Tuple
+	}{
+		// perfect cube splits on x
+		{tuples.PointNew(-1, -4, -5), tuples.PointNew(9, 6, 5), tuples.PointNew(4, 6, 5), tuples.PointNew(4, -4, -5)},
+		// wide box splits on x
+		{tuples.PointNew(-1, -2, -3), tuples.PointNew(9, 5.5, 3), tuples.PointNew(4, 5.5, 3), tuples.PointNew(4, -2, -3)},
+		// tall box splits on y
+		{tuples.PointNew(-1, -2, -3), tuples.PointNew(5, 8, 3), tuples.PointNew(5, 3, 3), tuples.PointNew(-1, 3, -3)},
+		// deep box splits on z
+		{tuples.PointNew(-1, -2, -3), tuples.PointNew(5, 3, 7), tuples.PointNew(5, 3, 2), tuples.PointNew(-1, -2, 2)},
+	}
+	for _, test := range tests {
+		left, right := BoundingBoxNew(test.min, test.max).Split()
+		if !left.Min.Equal(test.min) || !left.Max.Equal(test.leftMax) {
+			t.Errorf("got left %v want %v %v", left, test.min, test.leftMax)
+		}
+		if !right.Min.Equal(test.rightMin) || !right.Max.Equal(test.max) {
+			t.Errorf("got right %v want %v %v", right, test.rightMin, test.max)
+		}
+	}
+}
+
+// countingShape : a sphere that counts how often it is intersected
+type countingShape struct {
+	Sphere
+	count *int
+}
+
+func (c countingShape) Intersect(r rays.Ray) []Intersection {
+	*c.count++
+	return c.Sphere.Intersect(r)
+}
+
+func (c countingShape) setParent(parent Shape) Shape {
+	c.Sphere.Parent = parent
+	return c
+}
+
+func TestGroupIntersectSkipsMissedBounds(t *testing.T) {
+	count := 0
+	g := GroupNew()
+	g.AddChild(countingShape{SphereNew(), &count})
+	g.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 1, 0)))
+	if count != 0 {
+		t.Errorf("got %d want %d", count, 0)
+	}
+	g.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if count != 1 {
+		t.Errorf("got %d want %d", count, 1)
+	}
+}
+
+func TestGroupPartitionChildren(t *testing.T) {
+	s1 := SphereNew(transformations.TranslationNew(-2, 0, 0))
+	s2 := SphereNew(transformations.TranslationNew(2, 0, 0))
+	s3 := SphereNew()
+	g := GroupNew()
+	g.AddChild(s1, s2, s3)
+	left, right := g.PartitionChildren()
+	if len(g.Children) != 1 || len(left) != 1 || len(right) != 1 {
+		t.Fatalf("got %d %d %d want 1 1 1", len(g.Children), len(left), len(right))
+	}
+	if left[0].GetTransform() != s1.Transform || right[0].GetTransform() != s2.Transform {
+		t.Errorf("got %v %v", left, right)
+	}
+	if g.Children[0].GetTransform() != s3.Transform {
+		t.Errorf("got %v want %v", g.Children[0], s3)
+	}
+}
+
+func TestDivide(t *testing.T) {
+	s1 := SphereNew(transformations.TranslationNew(-2, -2, 0))
+	s2 := SphereNew(transformations.TranslationNew(-2, 2, 0))
+	s3 := SphereNew(transformations.ScalingNew(4, 4, 4))
+	g := GroupNew()
+	g.AddChild(s1, s2, s3)
+	Divide(g, 1)
+	if len(g.Children) != 2 {
+		t.Fatalf("got %d want %d", len(g.Children), 2)
+	}
+	if g.Children[0].GetTransform() != s3.Transform {
+		t.Errorf("got %v want %v", g.Children[0], s3)
+	}
+	sub := g.Children[1].(*Group)
+	if len(sub.Children) != 2 {
+		t.Fatalf("got %d want %d", len(sub.Children), 2)
+	}
+	for i, s := range []Sphere{s1, s2} {
+		leaf := sub.Children[i].(*Group)
+		if len(leaf.Children) != 1 || leaf.Children[0].GetTransform() != s.Transform {
+			t.Errorf("%d: got %v want %v", i, leaf.Children, s)
+		}
+	}
+}
+
+func TestDivideCoincidentChildren(t *testing.T) {
+	// children with zero-size bounds can't be split, and must not recurse
+	// forever
+	g := GroupNew()
+	p := tuples.PointNew(1, 1, 1)
+	g.AddChild(TriangleNew(p, p, p), TriangleNew(p, p, p))
+	Divide(g, 1)
+	if len(g.Children) != 2 {
+		t.Errorf("got %d want %d", len(g.Children), 2)
+	}
+}
+
+func TestDivideKeepsIntersections(t *testing.T) {
+	g := GroupNew()
+	for i := 0; i < 10; i++ {
+		g.AddChild(SphereNew(transformations.TranslationNew(float64(3*i), 0, 0)))
+	}
+	r := rays.RayNew(tuples.PointNew(-5, 0, 0), tuples.VectorNew(1, 0, 0))
+	before := len(g.Intersect(r))
+	Divide(g, 2)
+	after := len(g.Intersect(r))
+	if before != 20 || after != before {
+		t.Errorf("got %d then %d want %d", before, after, 20)
+	}
+}
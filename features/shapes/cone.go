@@ -0,0 +1,132 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/roots"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Cone : a Shape, the double-napped cone x^2 + z^2 = y^2 in object space
+//
+// the two nappes meet at the origin. like the cylinder, the cone is truncated
+// to Minimum < y < Maximum, and capped at both ends when Closed is set. the
+// radius of each cap is the absolute value of its y
+type Cone struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Minimum   float64
+	Maximum   float64
+	Closed    bool
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// ConeNew : cone constructor, infinitely long and open by default
+//
+// using variadic function to make transform optional
+func ConeNew(transform ...*mat.Dense) Cone {
+	c := Cone{
+		Transform: transformations.IdentityNew(4),
+		Material:  materials.MaterialNew(),
+		Minimum:   math.Inf(-1),
+		Maximum:   math.Inf(1),
+		Closed:    false,
+	}
+	if len(transform) > 0 {
+		c.Transform = transform[0]
+	}
+	c.id = shapeIDNew()
+	c.inverse = inverseCacheNew(c.Transform)
+	return c
+}
+
+// Intersect cone with ray
+func (c Cone) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(c.inverseTransform())
+	o, d := r.Origin, r.Direction
+	a := d.X*d.X - d.Y*d.Y + d.Z*d.Z
+	b := 2*o.X*d.X - 2*o.Y*d.Y + 2*o.Z*d.Z
+	cc := o.X*o.X - o.Y*o.Y + o.Z*o.Z
+	ts := []float64{}
+	if math.Abs(a) < tuples.EPSILON {
+		// “When a is zero, it means the ray is parallel to one of the cone’s
+		// halves. [...] the ray may still intersect the other half of the cone.”
+		// if b is zero too, the ray misses both halves
+		if math.Abs(b) >= tuples.EPSILON {
+			ts = append(ts, -cc/(2*b))
+		}
+	} else {
+		ts = roots.SolveQuadratic(a, b, cc)
+	}
+	// keep the intersections that fall between the truncation planes
+	xs := []Intersection{}
+	for _, t := range ts {
+		y := o.Y + t*d.Y
+		if c.Minimum < y && y < c.Maximum {
+			xs = append(xs, IntersectionNew(t, c))
+		}
+	}
+	caps := intersectCaps(c, r, c.Closed, c.Minimum, c.Maximum, math.Abs(c.Minimum), math.Abs(c.Maximum))
+	return append(xs, caps...)
+}
+
+// NormalAt : get the world space normal of the cone at a world space point
+func (c Cone) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(c, worldPoint)
+	// compute the square of the distance from the y axis
+	dist := p.X*p.X + p.Z*p.Z
+	var objectNormal tuples.Tuple
+	if dist < c.Maximum*c.Maximum && p.Y >= c.Maximum-tuples.EPSILON {
+		objectNormal = tuples.VectorNew(0, 1, 0)
+	} else if dist < c.Minimum*c.Minimum && p.Y <= c.Minimum+tuples.EPSILON {
+		objectNormal = tuples.VectorNew(0, -1, 0)
+	} else {
+		// the side normal leans away from the tip of each nappe
+		y := math.Sqrt(dist)
+		if p.Y > 0 {
+			y = -y
+		}
+		objectNormal = tuples.VectorNew(p.X, y, p.Z)
+	}
+	return NormalToWorld(c, objectNormal)
+}
+
+// GetMaterial : get the material of the cone
+func (c Cone) GetMaterial() materials.Material {
+	return c.Material
+}
+
+// GetTransform : get the transform of the cone
+func (c Cone) GetTransform() *mat.Dense {
+	return c.Transform
+}
+
+func (c Cone) inverseTransform() *mat.Dense {
+	return c.inverse.of(c.Transform)
+}
+
+func (c Cone) getID() uint64 {
+	return c.id
+}
+
+// GetParent : get the group or CSG containing the cone, if any
+func (c Cone) GetParent() Shape {
+	return c.Parent
+}
+
+func (c Cone) setParent(parent Shape) Shape {
+	c.Parent = parent
+	return c
+}
+
+// Bounds : the widest cap decides the x and z extent
+func (c Cone) Bounds() BoundingBox {
+	limit := math.Max(math.Abs(c.Minimum), math.Abs(c.Maximum))
+	return BoundingBoxNew(tuples.PointNew(-limit, c.Minimum, -limit), tuples.PointNew(limit, c.Maximum, limit))
+}
@@ -0,0 +1,115 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestConeIntersect(t *testing.T) {
+	c := ConeNew()
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		t0, t1    float64
+	}{
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1), 5, 5},
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(1, 1, 1), 8.66025, 8.66025},
+		{tuples.PointNew(1, 1, -5), tuples.VectorNew(-0.5, -1, 1), 4.55006, 49.44994},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != 2 {
+			t.Errorf("%v: got %d want %d", test.direction, len(xs), 2)
+			continue
+		}
+		if math.Abs(xs[0].IntersectionValue-test.t0) > 0.0001 {
+			t.Errorf("%v: got %f want %f", test.direction, xs[0].IntersectionValue, test.t0)
+		}
+		if math.Abs(xs[1].IntersectionValue-test.t1) > 0.0001 {
+			t.Errorf("%v: got %f want %f", test.direction, xs[1].IntersectionValue, test.t1)
+		}
+	}
+}
+
+func TestConeIntersectParallelToHalf(t *testing.T) {
+	c := ConeNew()
+	direction := tuples.VectorNew(0, 1, 1).Normalize()
+	xs := c.Intersect(rays.RayNew(tuples.PointNew(0, 0, -1), direction))
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if math.Abs(xs[0].IntersectionValue-0.35355) > 0.0001 {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 0.35355)
+	}
+}
+
+func TestConeIntersectThroughApexParallel(t *testing.T) {
+	// a ray along the surface through the apex has a == 0 and b == 0
+	c := ConeNew()
+	direction := tuples.VectorNew(0, 1, 1).Normalize()
+	xs := c.Intersect(rays.RayNew(tuples.PointNew(0, -1, -1), direction))
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestClosedConeIntersect(t *testing.T) {
+	c := ConeNew()
+	c.Minimum = -0.5
+	c.Maximum = 0.5
+	c.Closed = true
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		count     int
+	}{
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 1, 0), 0},
+		{tuples.PointNew(0, 0, -0.25), tuples.VectorNew(0, 1, 1), 2},
+		{tuples.PointNew(0, 0, -0.25), tuples.VectorNew(0, 1, 0), 4},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != test.count {
+			t.Errorf("%v: got %d want %d", test.direction, len(xs), test.count)
+		}
+	}
+}
+
+func TestConeNormalAt(t *testing.T) {
+	c := ConeNew()
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(1, 1, 1), tuples.VectorNew(1, -math.Sqrt(2), 1).Normalize()},
+		{tuples.PointNew(-1, -1, 0), tuples.VectorNew(-1, 1, 0).Normalize()},
+	}
+	for _, test := range tests {
+		got := c.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestClosedConeNormalAt(t *testing.T) {
+	c := ConeNew()
+	c.Minimum = -1
+	c.Maximum = 2
+	c.Closed = true
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(0.5, 2, 0), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0.5, -1, 0.5), tuples.VectorNew(0, -1, 0)},
+	}
+	for _, test := range tests {
+		got := c.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
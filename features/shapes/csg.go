@@ -0,0 +1,157 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// CSGOperation : how the two children of a CSG shape are combined
+type CSGOperation int
+
+const (
+	// CSGUnion : the combined surface of both children, without the parts of
+	// either that lie inside the other
+	CSGUnion CSGOperation = iota
+	// CSGIntersection : only the parts where both children overlap
+	CSGIntersection
+	// CSGDifference : the left child with the right child carved out of it
+	CSGDifference
+)
+
+// CSG : a Shape, constructive solid geometry combining two shapes
+//
+// like groups, CSG shapes are handled by pointer, so that the children can
+// refer back to them
+type CSG struct {
+	Operation   CSGOperation
+	Left, Right Shape
+	Transform   *mat.Dense
+	Parent      Shape
+	id          uint64
+	inverse     *inverseCache
+}
+
+// CSGNew : CSG constructor, setting the parent of both children
+//
+// using variadic function to make transform optional
+func CSGNew(operation CSGOperation, left, right Shape, transform ...*mat.Dense) *CSG {
+	c := &CSG{Operation: operation, Transform: transformations.IdentityNew(4)}
+	if len(transform) > 0 {
+		c.Transform = transform[0]
+	}
+	c.Left = left.setParent(c)
+	c.Right = right.setParent(c)
+	c.id = shapeIDNew()
+	c.inverse = inverseCacheNew(c.Transform)
+	return c
+}
+
+// IntersectionAllowed : decide whether a hit on one child is part of the
+// combined surface
+//
+// leftHit is true if the left child was hit, inLeft and inRight say whether
+// the hit lies inside the left and right children respectively
+func IntersectionAllowed(operation CSGOperation, leftHit, inLeft, inRight bool) bool {
+	switch operation {
+	case CSGUnion:
+		return (leftHit && !inRight) || (!leftHit && !inLeft)
+	case CSGIntersection:
+		return (leftHit && inRight) || (!leftHit && inLeft)
+	case CSGDifference:
+		return (leftHit && !inRight) || (!leftHit && inLeft)
+	}
+	return false
+}
+
+// includes : check if a shape is, or contains, another shape
+func includes(container, s Shape) bool {
+	switch c := container.(type) {
+	case *Group:
+		for _, child := range c.Children {
+			if includes(child, s) {
+				return true
+			}
+		}
+		return false
+	case *CSG:
+		return includes(c.Left, s) || includes(c.Right, s)
+	case *Instance:
+		// hits inside an instance are wrapped, see instanceHit
+		hit, ok := s.(instanceHit)
+		return ok && hit.instance == c
+	}
+	return sameShape(container, s)
+}
+
+// FilterIntersections : keep only the intersections on the combined surface
+//
+// the intersections must be sorted. walking them in order, each hit toggles
+// whether the ray is inside the child that was hit
+func (c *CSG) FilterIntersections(intersections []Intersection) []Intersection {
+	inLeft, inRight := false, false
+	result := []Intersection{}
+	for _, i := range intersections {
+		leftHit := includes(c.Left, i.Shape)
+		if IntersectionAllowed(c.Operation, leftHit, inLeft, inRight) {
+			result = append(result, i)
+		}
+		if leftHit {
+			inLeft = !inLeft
+		} else {
+			inRight = !inRight
+		}
+	}
+	return result
+}
+
+// Intersect CSG with ray
+func (c *CSG) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(c.inverseTransform())
+	intersections := append(c.Left.Intersect(r), c.Right.Intersect(r)...)
+	IntersectionSort(intersections)
+	return c.FilterIntersections(intersections)
+}
+
+// NormalAt : CSG shapes have no surface of their own
+//
+// intersections always refer to a child, so this should never be called
+func (c *CSG) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	panic("NormalAt called on a CSG, call it on the intersected child instead")
+}
+
+// GetMaterial : CSG shapes have no material of their own, so return the default
+func (c *CSG) GetMaterial() materials.Material {
+	return materials.MaterialNew()
+}
+
+// GetTransform : get the transform of the CSG
+func (c *CSG) GetTransform() *mat.Dense {
+	return c.Transform
+}
+
+func (c *CSG) inverseTransform() *mat.Dense {
+	return c.inverse.of(c.Transform)
+}
+
+func (c *CSG) getID() uint64 {
+	return c.id
+}
+
+// GetParent : get the group or CSG containing this CSG, if any
+func (c *CSG) GetParent() Shape {
+	return c.Parent
+}
+
+func (c *CSG) setParent(parent Shape) Shape {
+	c.Parent = parent
+	return c
+}
+
+// Bounds : the combined bounds of both children
+func (c *CSG) Bounds() BoundingBox {
+	return ParentSpaceBounds(c.Left).AddBox(ParentSpaceBounds(c.Right))
+}
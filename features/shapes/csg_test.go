@@ -0,0 +1,147 @@
+package shapes
+
+import (
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestCSGNew(t *testing.T) {
+	s1 := SphereNew()
+	s2 := CubeNew()
+	c := CSGNew(CSGUnion, s1, s2)
+	if c.Operation != CSGUnion {
+		t.Errorf("got %v want %v", c.Operation, CSGUnion)
+	}
+	if c.Left.GetParent() != c {
+		t.Errorf("got %v want %v", c.Left.GetParent(), c)
+	}
+	if c.Right.GetParent() != c {
+		t.Errorf("got %v want %v", c.Right.GetParent(), c)
+	}
+}
+
+func TestIntersectionAllowed(t *testing.T) {
+	tests := []struct {
+		operation                CSGOperation
+		leftHit, inLeft, inRight bool
+		want                     bool
+	}{
+		{CSGUnion, true, true, true, false},
+		{CSGUnion, true, true, false, true},
+		{CSGUnion, true, false, true, false},
+		{CSGUnion, true, false, false, true},
+		{CSGUnion, false, true, true, false},
+		{CSGUnion, false, true, false, false},
+		{CSGUnion, false, false, true, true},
+		{CSGUnion, false, false, false, true},
+		{CSGIntersection, true, true, true, true},
+		{CSGIntersection, true, true, false, false},
+		{CSGIntersection, true, false, true, true},
+		{CSGIntersection, true, false, false, false},
+		{CSGIntersection, false, true, true, true},
+		{CSGIntersection, false, true, false, true},
+		{CSGIntersection, false, false, true, false},
+		{CSGIntersection, false, false, false, false},
+		{CSGDifference, true, true, true, false},
+		{CSGDifference, true, true, false, true},
+		{CSGDifference, true, false, true, false},
+		{CSGDifference, true, false, false, true},
+		{CSGDifference, false, true, true, true},
+		{CSGDifference, false, true, false, true},
+		{CSGDifference, false, false, true, false},
+		{CSGDifference, false, false, false, false},
+	}
+	for _, test := range tests {
+		got := IntersectionAllowed(test.operation, test.leftHit, test.inLeft, test.inRight)
+		if got != test.want {
+			t.Errorf("%v: got %v want %v", test, got, test.want)
+		}
+	}
+}
+
+func TestCSGFilterIntersections(t *testing.T) {
+	tests := []struct {
+		operation CSGOperation
+		x0, x1    int
+	}{
+		{CSGUnion, 0, 3},
+		{CSGIntersection, 1, 2},
+		{CSGDifference, 0, 1},
+	}
+	for _, test := range tests {
+		c := CSGNew(test.operation, SphereNew(), CubeNew())
+		xs := []Intersection{
+			IntersectionNew(1, c.Left),
+			IntersectionNew(2, c.Right),
+			IntersectionNew(3, c.Left),
+			IntersectionNew(4, c.Right),
+		}
+		result := c.FilterIntersections(xs)
+		if len(result) != 2 {
+			t.Errorf("%v: got %d want %d", test.operation, len(result), 2)
+			continue
+		}
+		if result[0] != xs[test.x0] || result[1] != xs[test.x1] {
+			t.Errorf("%v: got %v want %v", test.operation, result, []Intersection{xs[test.x0], xs[test.x1]})
+		}
+	}
+}
+
+// children built with the same fields are still told apart
+func TestCSGFilterIntersectionsEqualChildren(t *testing.T) {
+	c := CSGNew(CSGDifference, SphereNew(), SphereNew())
+	xs := []Intersection{
+		IntersectionNew(1, c.Left),
+		IntersectionNew(2, c.Right),
+		IntersectionNew(3, c.Left),
+		IntersectionNew(4, c.Right),
+	}
+	result := c.FilterIntersections(xs)
+	if len(result) != 2 || result[0] != xs[0] || result[1] != xs[1] {
+		t.Errorf("got %v want %v", result, xs[:2])
+	}
+}
+
+func TestCSGIntersectMiss(t *testing.T) {
+	c := CSGNew(CSGUnion, SphereNew(), CubeNew())
+	r := rays.RayNew(tuples.PointNew(0, 2, -5), tuples.VectorNew(0, 0, 1))
+	xs := c.Intersect(r)
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestCSGIntersect(t *testing.T) {
+	c := CSGNew(CSGUnion, SphereNew(), SphereNew(transformations.TranslationNew(0, 0, 0.5)))
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := c.Intersect(r)
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 4) || xs[0].Shape != c.Left {
+		t.Errorf("got %v want %v", xs[0], IntersectionNew(4, c.Left))
+	}
+	if !tuples.FloatEqual(xs[1].IntersectionValue, 6.5) || xs[1].Shape != c.Right {
+		t.Errorf("got %v want %v", xs[1], IntersectionNew(6.5, c.Right))
+	}
+}
+
+func TestCSGIncludesNestedGroup(t *testing.T) {
+	g := GroupNew()
+	g.AddChild(SphereNew())
+	c := CSGNew(CSGDifference, g, CubeNew(transformations.TranslationNew(0, 0, 1)))
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := c.Intersect(r)
+	// the sphere from z = -1 to 1, minus the cube from z = 0 to 2
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 4) {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 4.0)
+	}
+	if !tuples.FloatEqual(xs[1].IntersectionValue, 5) {
+		t.Errorf("got %f want %f", xs[1].IntersectionValue, 5.0)
+	}
+}
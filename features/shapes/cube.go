@@ -0,0 +1,124 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Cube : a Shape, the axis-aligned box from -1 to 1 on every axis in object
+// space
+type Cube struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// CubeNew : cube constructor
+//
+// using variadic function to make transform optional
+func CubeNew(transform ...*mat.Dense) Cube {
+	c := Cube{transformations.IdentityNew(4), materials.MaterialNew(), nil, 0, nil}
+	if len(transform) > 0 {
+		c.Transform = transform[0]
+	}
+	c.id = shapeIDNew()
+	c.inverse = inverseCacheNew(c.Transform)
+	return c
+}
+
+// Intersect cube with ray
+//
+// “...treat the cube as if it were composed of six planes, one for each face of
+// the cube. [...] The largest minimum t value and the smallest maximum t value
+// give you the intersections with the cube itself.”
+func (c Cube) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(c.inverseTransform())
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, -1, 1)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, -1, 1)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, -1, 1)
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	// the ray misses the cube
+	if tmin > tmax {
+		return []Intersection{}
+	}
+	return []Intersection{IntersectionNew(tmin, c), IntersectionNew(tmax, c)}
+}
+
+// checkAxis : intersect a ray with the pair of slabs bounding one axis
+//
+// returns the entry and exit t values, in order. a ray parallel to the slabs
+// is either between them all along, and gets infinite values so the other
+// axes decide, or never between them, and gets an empty range
+func checkAxis(origin, direction, min, max float64) (float64, float64) {
+	// dividing by a tiny direction would give 0 * infinity = NaN for an
+	// origin right on a slab
+	if math.Abs(direction) < tuples.EPSILON {
+		if min <= origin && origin <= max {
+			return math.Inf(-1), math.Inf(1)
+		}
+		return math.Inf(1), math.Inf(-1)
+	}
+	tmin := (min - origin) / direction
+	tmax := (max - origin) / direction
+	if tmin > tmax {
+		tmin, tmax = tmax, tmin
+	}
+	return tmin, tmax
+}
+
+// NormalAt : the normal is the axis of the face the point lies on
+//
+// the face is the one whose component has the largest magnitude
+func (c Cube) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(c, worldPoint)
+	absX, absY, absZ := math.Abs(p.X), math.Abs(p.Y), math.Abs(p.Z)
+	maxc := math.Max(absX, math.Max(absY, absZ))
+	objectNormal := tuples.VectorNew(0, 0, p.Z)
+	if maxc == absX {
+		objectNormal = tuples.VectorNew(p.X, 0, 0)
+	} else if maxc == absY {
+		objectNormal = tuples.VectorNew(0, p.Y, 0)
+	}
+	return NormalToWorld(c, objectNormal)
+}
+
+// GetMaterial : get the material of the cube
+func (c Cube) GetMaterial() materials.Material {
+	return c.Material
+}
+
+// GetTransform : get the transform of the cube
+func (c Cube) GetTransform() *mat.Dense {
+	return c.Transform
+}
+
+func (c Cube) inverseTransform() *mat.Dense {
+	return c.inverse.of(c.Transform)
+}
+
+func (c Cube) getID() uint64 {
+	return c.id
+}
+
+// GetParent : get the group or CSG containing the cube, if any
+func (c Cube) GetParent() Shape {
+	return c.Parent
+}
+
+func (c Cube) setParent(parent Shape) Shape {
+	c.Parent = parent
+	return c
+}
+
+// Bounds : the object space bounds of the cube
+func (c Cube) Bounds() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(-1, -1, -1), tuples.PointNew(1, 1, 1))
+}
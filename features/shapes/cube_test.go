@@ -0,0 +1,104 @@
+package shapes
+
+import (
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestCubeIntersect(t *testing.T) {
+	c := CubeNew()
+	tests := []struct {
+		name      string
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		t1, t2    float64
+	}{
+		{"+x", tuples.PointNew(5, 0.5, 0), tuples.VectorNew(-1, 0, 0), 4, 6},
+		{"-x", tuples.PointNew(-5, 0.5, 0), tuples.VectorNew(1, 0, 0), 4, 6},
+		{"+y", tuples.PointNew(0.5, 5, 0), tuples.VectorNew(0, -1, 0), 4, 6},
+		{"-y", tuples.PointNew(0.5, -5, 0), tuples.VectorNew(0, 1, 0), 4, 6},
+		{"+z", tuples.PointNew(0.5, 0, 5), tuples.VectorNew(0, 0, -1), 4, 6},
+		{"-z", tuples.PointNew(0.5, 0, -5), tuples.VectorNew(0, 0, 1), 4, 6},
+		{"inside", tuples.PointNew(0, 0.5, 0), tuples.VectorNew(0, 0, 1), -1, 1},
+		// starting on the plane of a face and running along it
+		{"along a face", tuples.PointNew(1, 0.5, -5), tuples.VectorNew(0, 0, 1), 4, 6},
+		{"along an edge", tuples.PointNew(-1, 1, -5), tuples.VectorNew(0, 0, 1), 4, 6},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction))
+		if len(xs) != 2 {
+			t.Errorf("%s: got %d want %d", test.name, len(xs), 2)
+			continue
+		}
+		if !tuples.FloatEqual(xs[0].IntersectionValue, test.t1) {
+			t.Errorf("%s: got %f want %f", test.name, xs[0].IntersectionValue, test.t1)
+		}
+		if !tuples.FloatEqual(xs[1].IntersectionValue, test.t2) {
+			t.Errorf("%s: got %f want %f", test.name, xs[1].IntersectionValue, test.t2)
+		}
+	}
+}
+
+func TestCubeIntersectMiss(t *testing.T) {
+	c := CubeNew()
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+	}{
+		{tuples.PointNew(-2, 0, 0), tuples.VectorNew(0.2673, 0.5345, 0.8018)},
+		{tuples.PointNew(0, -2, 0), tuples.VectorNew(0.8018, 0.2673, 0.5345)},
+		{tuples.PointNew(0, 0, -2), tuples.VectorNew(0.5345, 0.8018, 0.2673)},
+		{tuples.PointNew(2, 0, 2), tuples.VectorNew(0, 0, -1)},
+		{tuples.PointNew(0, 2, 2), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(2, 2, 0), tuples.VectorNew(-1, 0, 0)},
+		{tuples.PointNew(1, 2, -5), tuples.VectorNew(0, 0, 1)},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction))
+		if len(xs) != 0 {
+			t.Errorf("%v: got %d want %d", test.origin, len(xs), 0)
+		}
+	}
+}
+
+func TestCubeNormalAt(t *testing.T) {
+	c := CubeNew()
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(1, 0.5, -0.8), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(-1, -0.2, 0.9), tuples.VectorNew(-1, 0, 0)},
+		{tuples.PointNew(-0.4, 1, -0.1), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0.3, -1, -0.7), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(-0.6, 0.3, 1), tuples.VectorNew(0, 0, 1)},
+		{tuples.PointNew(0.4, 0.4, -1), tuples.VectorNew(0, 0, -1)},
+		{tuples.PointNew(1, 1, 1), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(-1, -1, -1), tuples.VectorNew(-1, 0, 0)},
+	}
+	for _, test := range tests {
+		got := c.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestTransformedCubeIntersect(t *testing.T) {
+	c := CubeNew(transformations.ChainTransform(
+		transformations.TranslationNew(0, 1, 0),
+		transformations.ScalingNew(2, 1, 2)))
+	r := rays.RayNew(tuples.PointNew(0, 1, -5), tuples.VectorNew(0, 0, 1))
+	xs := c.Intersect(r)
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 3) {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 3.0)
+	}
+	if !tuples.FloatEqual(xs[1].IntersectionValue, 7) {
+		t.Errorf("got %f want %f", xs[1].IntersectionValue, 7.0)
+	}
+}
@@ -0,0 +1,151 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/roots"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Cylinder : a Shape, the unit radius cylinder around the y axis in object
+// space
+//
+// the cylinder is truncated to Minimum < y < Maximum (exclusive), and capped
+// at both ends when Closed is set
+type Cylinder struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Minimum   float64
+	Maximum   float64
+	Closed    bool
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// CylinderNew : cylinder constructor, infinitely long and open by default
+//
+// using variadic function to make transform optional
+func CylinderNew(transform ...*mat.Dense) Cylinder {
+	c := Cylinder{
+		Transform: transformations.IdentityNew(4),
+		Material:  materials.MaterialNew(),
+		Minimum:   math.Inf(-1),
+		Maximum:   math.Inf(1),
+		Closed:    false,
+	}
+	if len(transform) > 0 {
+		c.Transform = transform[0]
+	}
+	c.id = shapeIDNew()
+	c.inverse = inverseCacheNew(c.Transform)
+	return c
+}
+
+// Intersect cylinder with ray
+func (c Cylinder) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(c.inverseTransform())
+	xs := []Intersection{}
+	a := r.Direction.X*r.Direction.X + r.Direction.Z*r.Direction.Z
+	// a ray parallel to the y axis can only hit the caps
+	if math.Abs(a) >= tuples.EPSILON {
+		b := 2*r.Origin.X*r.Direction.X + 2*r.Origin.Z*r.Direction.Z
+		cc := r.Origin.X*r.Origin.X + r.Origin.Z*r.Origin.Z - 1
+		ts := roots.SolveQuadratic(a, b, cc)
+		// ray does not intersect the cylinder
+		if len(ts) == 0 {
+			return xs
+		}
+		// keep the intersections that fall between the truncation planes
+		for _, t := range ts {
+			y := r.Origin.Y + t*r.Direction.Y
+			if c.Minimum < y && y < c.Maximum {
+				xs = append(xs, IntersectionNew(t, c))
+			}
+		}
+	}
+	return append(xs, intersectCaps(c, r, c.Closed, c.Minimum, c.Maximum, 1, 1)...)
+}
+
+// intersectCaps : intersect a ray with the end caps of a cylinder or cone
+//
+// the caps are disks at y = minimum and y = maximum, with the given radii
+func intersectCaps(s Shape, r rays.Ray, closed bool, minimum, maximum, minRadius, maxRadius float64) []Intersection {
+	xs := []Intersection{}
+	// caps only matter if the shape is closed, and might possibly be
+	// intersected by the ray
+	if !closed || math.Abs(r.Direction.Y) < tuples.EPSILON {
+		return xs
+	}
+	// check for an intersection with the lower end cap by intersecting the
+	// ray with the plane at y = minimum
+	t := (minimum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, minRadius) {
+		xs = append(xs, IntersectionNew(t, s))
+	}
+	// check for an intersection with the upper end cap by intersecting the
+	// ray with the plane at y = maximum
+	t = (maximum - r.Origin.Y) / r.Direction.Y
+	if checkCap(r, t, maxRadius) {
+		xs = append(xs, IntersectionNew(t, s))
+	}
+	return xs
+}
+
+// checkCap : check if the intersection at t is within radius of the y axis
+func checkCap(r rays.Ray, t, radius float64) bool {
+	x := r.Origin.X + t*r.Direction.X
+	z := r.Origin.Z + t*r.Direction.Z
+	return (x*x + z*z) <= radius*radius+tuples.EPSILON
+}
+
+// NormalAt : get the world space normal of the cylinder at a world space point
+func (c Cylinder) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(c, worldPoint)
+	// compute the square of the distance from the y axis
+	dist := p.X*p.X + p.Z*p.Z
+	objectNormal := tuples.VectorNew(p.X, 0, p.Z)
+	if dist < 1 && p.Y >= c.Maximum-tuples.EPSILON {
+		objectNormal = tuples.VectorNew(0, 1, 0)
+	} else if dist < 1 && p.Y <= c.Minimum+tuples.EPSILON {
+		objectNormal = tuples.VectorNew(0, -1, 0)
+	}
+	return NormalToWorld(c, objectNormal)
+}
+
+// GetMaterial : get the material of the cylinder
+func (c Cylinder) GetMaterial() materials.Material {
+	return c.Material
+}
+
+// GetTransform : get the transform of the cylinder
+func (c Cylinder) GetTransform() *mat.Dense {
+	return c.Transform
+}
+
+func (c Cylinder) inverseTransform() *mat.Dense {
+	return c.inverse.of(c.Transform)
+}
+
+func (c Cylinder) getID() uint64 {
+	return c.id
+}
+
+// GetParent : get the group or CSG containing the cylinder, if any
+func (c Cylinder) GetParent() Shape {
+	return c.Parent
+}
+
+func (c Cylinder) setParent(parent Shape) Shape {
+	c.Parent = parent
+	return c
+}
+
+// Bounds : the object space bounds of the cylinder
+func (c Cylinder) Bounds() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(-1, c.Minimum, -1), tuples.PointNew(1, c.Maximum, 1))
+}
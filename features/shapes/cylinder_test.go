@@ -0,0 +1,158 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestCylinderNew(t *testing.T) {
+	c := CylinderNew()
+	if !math.IsInf(c.Minimum, -1) {
+		t.Errorf("got %f want %f", c.Minimum, math.Inf(-1))
+	}
+	if !math.IsInf(c.Maximum, 1) {
+		t.Errorf("got %f want %f", c.Maximum, math.Inf(1))
+	}
+	if c.Closed {
+		t.Errorf("got %v want %v", c.Closed, false)
+	}
+}
+
+func TestCylinderIntersectMiss(t *testing.T) {
+	c := CylinderNew()
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+	}{
+		{tuples.PointNew(1, 0, 0), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(1, 1, 1)},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != 0 {
+			t.Errorf("%v: got %d want %d", test.origin, len(xs), 0)
+		}
+	}
+}
+
+func TestCylinderIntersect(t *testing.T) {
+	c := CylinderNew()
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		t0, t1    float64
+	}{
+		{tuples.PointNew(1, 0, -5), tuples.VectorNew(0, 0, 1), 5, 5},
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1), 4, 6},
+		{tuples.PointNew(0.5, 0, -5), tuples.VectorNew(0.1, 1, 1), 6.80798, 7.08872},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != 2 {
+			t.Errorf("%v: got %d want %d", test.origin, len(xs), 2)
+			continue
+		}
+		if math.Abs(xs[0].IntersectionValue-test.t0) > 0.0001 {
+			t.Errorf("%v: got %f want %f", test.origin, xs[0].IntersectionValue, test.t0)
+		}
+		if math.Abs(xs[1].IntersectionValue-test.t1) > 0.0001 {
+			t.Errorf("%v: got %f want %f", test.origin, xs[1].IntersectionValue, test.t1)
+		}
+	}
+}
+
+func TestCylinderNormalAt(t *testing.T) {
+	c := CylinderNew()
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(1, 0, 0), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(0, 5, -1), tuples.VectorNew(0, 0, -1)},
+		{tuples.PointNew(0, -2, 1), tuples.VectorNew(0, 0, 1)},
+		{tuples.PointNew(-1, 1, 0), tuples.VectorNew(-1, 0, 0)},
+	}
+	for _, test := range tests {
+		got := c.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestTruncatedCylinderIntersect(t *testing.T) {
+	c := CylinderNew()
+	c.Minimum = 1
+	c.Maximum = 2
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		count     int
+	}{
+		{tuples.PointNew(0, 1.5, 0), tuples.VectorNew(0.1, 1, 0), 0},
+		{tuples.PointNew(0, 3, -5), tuples.VectorNew(0, 0, 1), 0},
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1), 0},
+		{tuples.PointNew(0, 2, -5), tuples.VectorNew(0, 0, 1), 0},
+		{tuples.PointNew(0, 1, -5), tuples.VectorNew(0, 0, 1), 0},
+		{tuples.PointNew(0, 1.5, -2), tuples.VectorNew(0, 0, 1), 2},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != test.count {
+			t.Errorf("%v: got %d want %d", test.origin, len(xs), test.count)
+		}
+	}
+}
+
+func TestClosedCylinderIntersect(t *testing.T) {
+	c := CylinderNew()
+	c.Minimum = 1
+	c.Maximum = 2
+	c.Closed = true
+	tests := []struct {
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		count     int
+	}{
+		{tuples.PointNew(0, 3, 0), tuples.VectorNew(0, -1, 0), 2},
+		{tuples.PointNew(0, 3, -2), tuples.VectorNew(0, -1, 2), 2},
+		// corner case
+		{tuples.PointNew(0, 4, -2), tuples.VectorNew(0, -1, 1), 2},
+		{tuples.PointNew(0, 0, -2), tuples.VectorNew(0, 1, 2), 2},
+		// corner case
+		{tuples.PointNew(0, -1, -2), tuples.VectorNew(0, 1, 1), 2},
+	}
+	for _, test := range tests {
+		xs := c.Intersect(rays.RayNew(test.origin, test.direction.Normalize()))
+		if len(xs) != test.count {
+			t.Errorf("%v: got %d want %d", test.origin, len(xs), test.count)
+		}
+	}
+}
+
+func TestClosedCylinderNormalAt(t *testing.T) {
+	c := CylinderNew()
+	c.Minimum = 1
+	c.Maximum = 2
+	c.Closed = true
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(0, 1, 0), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(0.5, 1, 0), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(0, 1, 0.5), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(0, 2, 0), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0.5, 2, 0), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0, 2, 0.5), tuples.VectorNew(0, 1, 0)},
+	}
+	for _, test := range tests {
+		got := c.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Disk : a Shape, a flat disk in the xz plane of object space, facing +y
+//
+// setting InnerRadius above zero cuts a hole in the middle, making an annulus.
+// intersections record u as the angle around the y axis and v as the
+// distance from the inner to the outer edge, both from 0 to 1
+type Disk struct {
+	Transform   *mat.Dense
+	Material    materials.Material
+	Radius      float64
+	InnerRadius float64
+	Parent      Shape
+	id          uint64
+	inverse     *inverseCache
+}
+
+// DiskNew : disk constructor, a unit radius disk with no hole by default
+//
+// using variadic function to make transform optional
+func DiskNew(transform ...*mat.Dense) Disk {
+	d := Disk{
+		Transform:   transformations.IdentityNew(4),
+		Material:    materials.MaterialNew(),
+		Radius:      1,
+		InnerRadius: 0,
+	}
+	if len(transform) > 0 {
+		d.Transform = transform[0]
+	}
+	d.id = shapeIDNew()
+	d.inverse = inverseCacheNew(d.Transform)
+	return d
+}
+
+// Intersect disk with ray
+func (d Disk) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(d.inverseTransform())
+	// a ray parallel to the disk (or coplanar with it) never hits it
+	if math.Abs(r.Direction.Y) < tuples.EPSILON {
+		return []Intersection{}
+	}
+	// find where the ray crosses y = 0, then check it lies between the radii
+	t := -r.Origin.Y / r.Direction.Y
+	p := r.Position(t)
+	distance := math.Sqrt(p.X*p.X + p.Z*p.Z)
+	if distance > d.Radius || distance < d.InnerRadius {
+		return []Intersection{}
+	}
+	u := math.Atan2(p.Z, p.X) / (2 * math.Pi)
+	if u < 0 {
+		u++
+	}
+	v := 0.0
+	if d.Radius > d.InnerRadius {
+		v = (distance - d.InnerRadius) / (d.Radius - d.InnerRadius)
+	}
+	return []Intersection{IntersectionWithUVNew(t, d, u, v)}
+}
+
+// NormalAt : the disk has the same normal everywhere
+func (d Disk) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	return NormalToWorld(d, tuples.VectorNew(0, 1, 0))
+}
+
+// Bounds : the object space bounds of the disk
+func (d Disk) Bounds() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(-d.Radius, 0, -d.Radius), tuples.PointNew(d.Radius, 0, d.Radius))
+}
+
+// GetMaterial : get the material of the disk
+func (d Disk) GetMaterial() materials.Material {
+	return d.Material
+}
+
+// GetTransform : get the transform of the disk
+func (d Disk) GetTransform() *mat.Dense {
+	return d.Transform
+}
+
+func (d Disk) inverseTransform() *mat.Dense {
+	return d.inverse.of(d.Transform)
+}
+
+func (d Disk) getID() uint64 {
+	return d.id
+}
+
+// GetParent : get the group or CSG containing the disk, if any
+func (d Disk) GetParent() Shape {
+	return d.Parent
+}
+
+func (d Disk) setParent(parent Shape) Shape {
+	d.Parent = parent
+	return d
+}
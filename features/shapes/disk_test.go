@@ -0,0 +1,79 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestDiskNormalAt(t *testing.T) {
+	d := DiskNew()
+	got := d.NormalAt(tuples.PointNew(0.5, 0, 0.5))
+	want := tuples.VectorNew(0, 1, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestTransformedDiskNormalAt(t *testing.T) {
+	d := DiskNew(transformations.RotationXNew(math.Pi / 2))
+	got := d.NormalAt(tuples.PointNew(0, 0, 0))
+	want := tuples.VectorNew(0, 0, 1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestDiskIntersect(t *testing.T) {
+	d := DiskNew()
+	d.InnerRadius = 0.5
+	tests := []struct {
+		name   string
+		origin tuples.Tuple
+		dir    tuples.Tuple
+		count  int
+	}{
+		{"through the ring", tuples.PointNew(0.75, 1, 0), tuples.VectorNew(0, -1, 0), 1},
+		{"through the hole", tuples.PointNew(0.25, 1, 0), tuples.VectorNew(0, -1, 0), 0},
+		{"outside the edge", tuples.PointNew(1.5, 1, 0), tuples.VectorNew(0, -1, 0), 0},
+		{"parallel", tuples.PointNew(0.75, 1, 0), tuples.VectorNew(1, 0, 0), 0},
+		{"coplanar", tuples.PointNew(0.75, 0, 0), tuples.VectorNew(0, 0, 1), 0},
+	}
+	for _, tt := range tests {
+		r := rays.RayNew(tt.origin, tt.dir)
+		xs := d.Intersect(r)
+		if len(xs) != tt.count {
+			t.Errorf("%s: got %d want %d", tt.name, len(xs), tt.count)
+		}
+	}
+}
+
+func TestDiskIntersectUV(t *testing.T) {
+	d := DiskNew()
+	d.Radius = 2
+	d.InnerRadius = 1
+	r := rays.RayNew(tuples.PointNew(0, 1, 1.5), tuples.VectorNew(0, -1, 0))
+	xs := d.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 1) {
+		t.Errorf("got %v want %v", xs[0].IntersectionValue, 1)
+	}
+	// a quarter turn from +x towards +z, halfway across the ring
+	if !tuples.FloatEqual(xs[0].U, 0.25) || !tuples.FloatEqual(xs[0].V, 0.5) {
+		t.Errorf("got (%v, %v) want (%v, %v)", xs[0].U, xs[0].V, 0.25, 0.5)
+	}
+}
+
+func TestDiskBounds(t *testing.T) {
+	d := DiskNew()
+	d.Radius = 3
+	got := d.Bounds()
+	want := BoundingBoxNew(tuples.PointNew(-3, 0, -3), tuples.PointNew(3, 0, 3))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
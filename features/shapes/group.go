@@ -0,0 +1,188 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Group : a Shape, a collection of child shapes sharing a transform
+//
+// groups are handled by pointer, so that children can refer back to the group
+// that contains them, and groups can be nested. a child is stored by value,
+// so changes to a shape after it is added do not affect the group's copy.
+// always add children with AddChild, so the cached bounds stay up to date
+type Group struct {
+	Transform *mat.Dense
+	Children  []Shape
+	Parent    Shape
+	// bounds : cached by Bounds, cleared when a child is added
+	bounds  *BoundingBox
+	id      uint64
+	inverse *inverseCache
+}
+
+// GroupNew : group constructor
+//
+// using variadic function to make transform optional
+func GroupNew(transform ...*mat.Dense) *Group {
+	g := &Group{transformations.IdentityNew(4), []Shape{}, nil, nil, 0, nil}
+	if len(transform) > 0 {
+		g.Transform = transform[0]
+	}
+	g.id = shapeIDNew()
+	g.inverse = inverseCacheNew(g.Transform)
+	return g
+}
+
+// AddChild : add shapes to the group, setting their parent to the group
+func (g *Group) AddChild(children ...Shape) {
+	for _, child := range children {
+		g.Children = append(g.Children, child.setParent(g))
+	}
+	g.invalidateBounds()
+}
+
+// invalidateBounds : clear the cached bounds of the group and every group
+// containing it
+func (g *Group) invalidateBounds() {
+	g.bounds = nil
+	if parent, ok := g.Parent.(*Group); ok {
+		parent.invalidateBounds()
+	}
+}
+
+// Bounds : the combined bounds of every child, in the group's object space
+//
+// children with empty bounds, such as empty groups, are skipped
+func (g *Group) Bounds() BoundingBox {
+	if g.bounds == nil {
+		b := BoundingBoxNew()
+		for _, child := range g.Children {
+			if childBounds := ParentSpaceBounds(child); !childBounds.IsEmpty() {
+				b = b.AddBox(childBounds)
+			}
+		}
+		g.bounds = &b
+	}
+	return *g.bounds
+}
+
+// Intersect group with ray
+//
+// the ray is converted to the group's object space, then intersected with
+// every child. if the ray misses the group's bounding box, none of the
+// children are tested
+func (g *Group) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(g.inverseTransform())
+	intersections := []Intersection{}
+	if !g.Bounds().Intersects(r) {
+		return intersections
+	}
+	for _, child := range g.Children {
+		intersections = append(intersections, child.Intersect(r)...)
+	}
+	IntersectionSort(intersections)
+	return intersections
+}
+
+// NormalAt : groups have no surface of their own
+//
+// intersections always refer to a child, so this should never be called
+func (g *Group) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	panic("NormalAt called on a group, call it on the intersected child instead")
+}
+
+// GetMaterial : groups have no material of their own, so return the default
+func (g *Group) GetMaterial() materials.Material {
+	return materials.MaterialNew()
+}
+
+// GetTransform : get the transform of the group
+func (g *Group) GetTransform() *mat.Dense {
+	return g.Transform
+}
+
+func (g *Group) inverseTransform() *mat.Dense {
+	return g.inverse.of(g.Transform)
+}
+
+func (g *Group) getID() uint64 {
+	return g.id
+}
+
+// GetParent : get the group or CSG containing this group, if any
+func (g *Group) GetParent() Shape {
+	return g.Parent
+}
+
+func (g *Group) setParent(parent Shape) Shape {
+	g.Parent = parent
+	return g
+}
+
+// PartitionChildren : move the children that fit in either half of the
+// group's bounds out of the group
+//
+// children straddling both halves are left in the group
+func (g *Group) PartitionChildren() ([]Shape, []Shape) {
+	leftBox, rightBox := g.Bounds().Split()
+	left, right, remaining := []Shape{}, []Shape{}, []Shape{}
+	for _, child := range g.Children {
+		childBox := ParentSpaceBounds(child)
+		if leftBox.ContainsBox(childBox) {
+			left = append(left, child)
+		} else if rightBox.ContainsBox(childBox) {
+			right = append(right, child)
+		} else {
+			remaining = append(remaining, child)
+		}
+	}
+	g.Children = remaining
+	g.invalidateBounds()
+	return left, right
+}
+
+// MakeSubgroup : add a new group to the group, containing the given shapes
+func (g *Group) MakeSubgroup(children ...Shape) {
+	subgroup := GroupNew()
+	subgroup.AddChild(children...)
+	g.AddChild(subgroup)
+}
+
+// Divide : build a bounding volume hierarchy below a shape
+//
+// groups with at least threshold children are split in half along their
+// longest axis, recursively, so rays can skip whole halves of a mesh. shapes
+// that are not groups or CSG are left as they are
+func Divide(s Shape, threshold int) {
+	switch c := s.(type) {
+	case *Group:
+		if count := len(c.Children); threshold <= count {
+			children := c.Children
+			left, right := c.PartitionChildren()
+			// a box too thin to split puts every child on the same side,
+			// and would be divided forever
+			if len(left) == count || len(right) == count {
+				c.Children = children
+				c.invalidateBounds()
+				return
+			}
+			if len(left) > 0 {
+				c.MakeSubgroup(left...)
+			}
+			if len(right) > 0 {
+				c.MakeSubgroup(right...)
+			}
+		}
+		for _, child := range c.Children {
+			Divide(child, threshold)
+		}
+	case *CSG:
+		Divide(c.Left, threshold)
+		Divide(c.Right, threshold)
+	}
+}
@@ -0,0 +1,109 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestGroupNew(t *testing.T) {
+	g := GroupNew()
+	if len(g.Children) != 0 {
+		t.Errorf("got %d want %d", len(g.Children), 0)
+	}
+	if g.Parent != nil {
+		t.Errorf("got %v want %v", g.Parent, nil)
+	}
+}
+
+func TestGroupAddChild(t *testing.T) {
+	g := GroupNew()
+	s := SphereNew()
+	g.AddChild(s)
+	if len(g.Children) != 1 {
+		t.Fatalf("got %d want %d", len(g.Children), 1)
+	}
+	if g.Children[0].GetParent() != g {
+		t.Errorf("got %v want %v", g.Children[0].GetParent(), g)
+	}
+}
+
+func TestGroupIntersectEmpty(t *testing.T) {
+	g := GroupNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1))
+	xs := g.Intersect(r)
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestGroupIntersect(t *testing.T) {
+	g := GroupNew()
+	s1 := SphereNew()
+	s2 := SphereNew(transformations.TranslationNew(0, 0, -3))
+	s3 := SphereNew(transformations.TranslationNew(5, 0, 0))
+	g.AddChild(s1, s2, s3)
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := g.Intersect(r)
+	if len(xs) != 4 {
+		t.Fatalf("got %d want %d", len(xs), 4)
+	}
+	want := []Shape{g.Children[1], g.Children[1], g.Children[0], g.Children[0]}
+	for i := range want {
+		if xs[i].Shape != want[i] {
+			t.Errorf("%d: got %v want %v", i, xs[i].Shape, want[i])
+		}
+	}
+}
+
+func TestTransformedGroupIntersect(t *testing.T) {
+	g := GroupNew(transformations.ScalingNew(2, 2, 2))
+	g.AddChild(SphereNew(transformations.TranslationNew(5, 0, 0)))
+	r := rays.RayNew(tuples.PointNew(10, 0, -10), tuples.VectorNew(0, 0, 1))
+	xs := g.Intersect(r)
+	if len(xs) != 2 {
+		t.Errorf("got %d want %d", len(xs), 2)
+	}
+}
+
+func TestWorldToObjectNested(t *testing.T) {
+	g1 := GroupNew(transformations.RotationYNew(math.Pi / 2))
+	g2 := GroupNew(transformations.ScalingNew(2, 2, 2))
+	g1.AddChild(g2)
+	g2.AddChild(SphereNew(transformations.TranslationNew(5, 0, 0)))
+	s := g2.Children[0]
+	got := WorldToObject(s, tuples.PointNew(-2, 0, -10))
+	want := tuples.PointNew(0, 0, -1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestNormalToWorldNested(t *testing.T) {
+	g1 := GroupNew(transformations.RotationYNew(math.Pi / 2))
+	g2 := GroupNew(transformations.ScalingNew(1, 2, 3))
+	g1.AddChild(g2)
+	g2.AddChild(SphereNew(transformations.TranslationNew(5, 0, 0)))
+	s := g2.Children[0]
+	v := math.Sqrt(3) / 3
+	got := NormalToWorld(s, tuples.VectorNew(v, v, v))
+	want := tuples.VectorNew(0.28571, 0.42857, -0.85714)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestNormalAtNested(t *testing.T) {
+	g1 := GroupNew(transformations.RotationYNew(math.Pi / 2))
+	g2 := GroupNew(transformations.ScalingNew(1, 2, 3))
+	g1.AddChild(g2)
+	g2.AddChild(SphereNew(transformations.TranslationNew(5, 0, 0)))
+	s := g2.Children[0]
+	got := s.NormalAt(tuples.PointNew(1.7321, 1.1547, -5.5774))
+	want := tuples.VectorNew(0.2857, 0.42854, -0.85716)
+	if math.Abs(got.X-want.X) > 0.0001 || math.Abs(got.Y-want.Y) > 0.0001 || math.Abs(got.Z-want.Z) > 0.0001 {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,265 @@
+package shapes
+
+import (
+	"fmt"
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/canvas"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Heightfield : a Shape, a terrain surface over a grid of heights
+//
+// Heights[i][j] is the height at x = i / (columns - 1), z = j / (rows - 1), so
+// the terrain covers x and z from 0 to 1 in object space. each grid cell is
+// split into two triangles along its diagonal. intersections record u and v
+// as the x and z of the hit. heightfields are handled by pointer, because the
+// grid of heights cannot be compared. the constructor checks the grid, so
+// change the heights by constructing a new heightfield
+type Heightfield struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Heights   [][]float64
+	Parent    Shape
+	// minHeight, maxHeight : the vertical extent of Heights
+	minHeight, maxHeight float64
+	id                   uint64
+	inverse              *inverseCache
+}
+
+// HeightfieldNew : heightfield constructor
+//
+// the grid must be rectangular, with at least two heights in each direction,
+// or an error is returned. using variadic function to make transform optional
+func HeightfieldNew(heights [][]float64, transform ...*mat.Dense) (*Heightfield, error) {
+	if len(heights) < 2 || len(heights[0]) < 2 {
+		rows := 0
+		if len(heights) > 0 {
+			rows = len(heights[0])
+		}
+		return nil, fmt.Errorf("heightfield needs at least 2x2 heights, got %dx%d", len(heights), rows)
+	}
+	for i, column := range heights {
+		if len(column) != len(heights[0]) {
+			return nil, fmt.Errorf("heightfield column %d has %d heights, want %d", i, len(column), len(heights[0]))
+		}
+	}
+	h := &Heightfield{
+		Transform: transformations.IdentityNew(4),
+		Material:  materials.MaterialNew(),
+		Heights:   heights,
+		minHeight: math.Inf(1),
+		maxHeight: math.Inf(-1),
+	}
+	if len(transform) > 0 {
+		h.Transform = transform[0]
+	}
+	for _, column := range heights {
+		for _, height := range column {
+			h.minHeight = math.Min(h.minHeight, height)
+			h.maxHeight = math.Max(h.maxHeight, height)
+		}
+	}
+	h.id = shapeIDNew()
+	h.inverse = inverseCacheNew(h.Transform)
+	return h, nil
+}
+
+// HeightfieldFromCanvas : heightfield constructor, taking heights from the
+// brightness of each pixel of an image, such as one read by
+// canvas.CanvasFromPPMPath
+//
+// pixel x, y gives Heights[x][y], so image rows run along z. the image must
+// be at least 2x2 pixels. using variadic function to make transform optional
+func HeightfieldFromCanvas(c canvas.Canvas, transform ...*mat.Dense) (*Heightfield, error) {
+	heights := make([][]float64, c.Width())
+	for i := range heights {
+		heights[i] = make([]float64, c.Height())
+		for j := range heights[i] {
+			pixel := c.GetPixel(i, j)
+			heights[i][j] = (pixel.X + pixel.Y + pixel.Z) / 3
+		}
+	}
+	return HeightfieldNew(heights, transform...)
+}
+
+// Intersect heightfield with ray
+//
+// the ray is clipped to the bounds of the terrain, then walks the grid one
+// cell at a time in the order it crosses them (a 2D DDA). only cells whose
+// heights overlap the ray's height across the cell are triangulated and tested
+func (h *Heightfield) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(h.inverseTransform())
+	intersections := []Intersection{}
+	columns, rows := h.cells()
+	if columns < 1 || rows < 1 {
+		return intersections
+	}
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, 0, 1)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, h.minHeight, h.maxHeight)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, 0, 1)
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	// the ray misses the terrain's bounds
+	if tmin > tmax {
+		return intersections
+	}
+	// start in the cell where the ray enters the bounds
+	entry := r.Position(tmin)
+	i, stepI, nextI, deltaI := ddaAxis(entry.X, r.Direction.X, columns, tmin)
+	j, stepJ, nextJ, deltaJ := ddaAxis(entry.Z, r.Direction.Z, rows, tmin)
+	t := tmin
+	for i >= 0 && i < columns && j >= 0 && j < rows {
+		exit := math.Min(tmax, math.Min(nextI, nextJ))
+		// skip cells the ray passes entirely above or below
+		yEnter, yExit := r.Position(t).Y, r.Position(exit).Y
+		low, high := h.cellRange(i, j)
+		if math.Max(yEnter, yExit) >= low-tuples.EPSILON && math.Min(yEnter, yExit) <= high+tuples.EPSILON {
+			for _, x := range h.intersectCell(r, i, j) {
+				// a hit on the edge between cells is found by both of them
+				if x.IntersectionValue < t-tuples.EPSILON || x.IntersectionValue > exit+tuples.EPSILON {
+					continue
+				}
+				if n := len(intersections); n > 0 &&
+					tuples.FloatEqual(intersections[n-1].IntersectionValue, x.IntersectionValue) {
+					continue
+				}
+				intersections = append(intersections, x)
+			}
+		}
+		if exit >= tmax {
+			break
+		}
+		// move into whichever neighboring cell the ray reaches first
+		if nextI < nextJ {
+			i += stepI
+			t = nextI
+			nextI += deltaI
+		} else {
+			j += stepJ
+			t = nextJ
+			nextJ += deltaJ
+		}
+	}
+	return intersections
+}
+
+// ddaAxis : set up the walk along one axis of the grid
+//
+// returns the starting cell, the direction to step in, the t value where the
+// ray crosses into the next cell, and how much t grows per cell. an axis the
+// ray doesn't move along is never stepped
+func ddaAxis(position, direction float64, cells int, tmin float64) (int, int, float64, float64) {
+	size := 1 / float64(cells)
+	cell := int(math.Floor(position * float64(cells)))
+	cell = int(math.Max(0, math.Min(float64(cells-1), float64(cell))))
+	switch {
+	case direction >= tuples.EPSILON:
+		return cell, 1, tmin + (float64(cell+1)*size-position)/direction, size / direction
+	case direction <= -tuples.EPSILON:
+		return cell, -1, tmin + (float64(cell)*size-position)/direction, -size / direction
+	default:
+		return cell, 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// cells : the number of grid cells along x and z
+func (h *Heightfield) cells() (int, int) {
+	if len(h.Heights) == 0 {
+		return 0, 0
+	}
+	return len(h.Heights) - 1, len(h.Heights[0]) - 1
+}
+
+// cellRange : the lowest and highest corners of a cell
+func (h *Heightfield) cellRange(i, j int) (float64, float64) {
+	corners := []float64{h.Heights[i][j], h.Heights[i+1][j], h.Heights[i][j+1], h.Heights[i+1][j+1]}
+	low, high := corners[0], corners[0]
+	for _, c := range corners[1:] {
+		low = math.Min(low, c)
+		high = math.Max(high, c)
+	}
+	return low, high
+}
+
+// corner : the object space point at grid index i, j
+func (h *Heightfield) corner(i, j int) tuples.Tuple {
+	columns, rows := h.cells()
+	return tuples.PointNew(float64(i)/float64(columns), h.Heights[i][j], float64(j)/float64(rows))
+}
+
+// cellTriangles : the two triangles of a cell, split along the diagonal from
+// i, j to i+1, j+1, each as a point and two edges
+func (h *Heightfield) cellTriangles(i, j int) [2][3]tuples.Tuple {
+	p00, p10 := h.corner(i, j), h.corner(i+1, j)
+	p01, p11 := h.corner(i, j+1), h.corner(i+1, j+1)
+	return [2][3]tuples.Tuple{
+		{p00, p10.Subtract(p00), p11.Subtract(p00)},
+		{p00, p11.Subtract(p00), p01.Subtract(p00)},
+	}
+}
+
+// intersectCell : intersect the ray with both triangles of a cell, in order
+func (h *Heightfield) intersectCell(r rays.Ray, i, j int) []Intersection {
+	intersections := []Intersection{}
+	for _, triangle := range h.cellTriangles(i, j) {
+		if t, _, _, ok := intersectTriangle(r, triangle[0], triangle[1], triangle[2]); ok {
+			p := r.Position(t)
+			intersections = append(intersections, IntersectionWithUVNew(t, h, p.X, p.Z))
+		}
+	}
+	IntersectionSort(intersections)
+	return intersections
+}
+
+// NormalAt : the normal of the triangle under the point
+func (h *Heightfield) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(h, worldPoint)
+	columns, rows := h.cells()
+	gx, gz := p.X*float64(columns), p.Z*float64(rows)
+	i := int(math.Max(0, math.Min(float64(columns-1), math.Floor(gx))))
+	j := int(math.Max(0, math.Min(float64(rows-1), math.Floor(gz))))
+	// the first triangle covers the half of the cell where x is ahead of z
+	triangle := h.cellTriangles(i, j)[1]
+	if gx-float64(i) >= gz-float64(j) {
+		triangle = h.cellTriangles(i, j)[0]
+	}
+	return NormalToWorld(h, triangle[2].CrossProduct(triangle[1]))
+}
+
+// Bounds : the object space bounds of the heightfield
+func (h *Heightfield) Bounds() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(0, h.minHeight, 0), tuples.PointNew(1, h.maxHeight, 1))
+}
+
+// GetMaterial : get the material of the heightfield
+func (h *Heightfield) GetMaterial() materials.Material {
+	return h.Material
+}
+
+// GetTransform : get the transform of the heightfield
+func (h *Heightfield) GetTransform() *mat.Dense {
+	return h.Transform
+}
+
+func (h *Heightfield) inverseTransform() *mat.Dense {
+	return h.inverse.of(h.Transform)
+}
+
+func (h *Heightfield) getID() uint64 {
+	return h.id
+}
+
+// GetParent : get the group or CSG containing the heightfield, if any
+func (h *Heightfield) GetParent() Shape {
+	return h.Parent
+}
+
+func (h *Heightfield) setParent(parent Shape) Shape {
+	h.Parent = parent
+	return h
+}
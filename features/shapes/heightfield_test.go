@@ -0,0 +1,190 @@
+package shapes
+
+import (
+	"math"
+	"math/rand"
+	"sarim-tracer/features/canvas"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func heightGrid(columns, rows int, height func(x, z float64) float64) [][]float64 {
+	heights := make([][]float64, columns)
+	for i := range heights {
+		heights[i] = make([]float64, rows)
+		for j := range heights[i] {
+			heights[i][j] = height(float64(i)/float64(columns-1), float64(j)/float64(rows-1))
+		}
+	}
+	return heights
+}
+
+func TestHeightfieldIntersectFlat(t *testing.T) {
+	h, err := HeightfieldNew(heightGrid(3, 3, func(x, z float64) float64 { return 0.5 }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rays.RayNew(tuples.PointNew(0.3, 2, 0.7), tuples.VectorNew(0, -1, 0))
+	xs := h.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 1.5) {
+		t.Errorf("got %v want %v", xs[0].IntersectionValue, 1.5)
+	}
+	if !tuples.FloatEqual(xs[0].U, 0.3) || !tuples.FloatEqual(xs[0].V, 0.7) {
+		t.Errorf("got (%v, %v) want (%v, %v)", xs[0].U, xs[0].V, 0.3, 0.7)
+	}
+	got := h.NormalAt(r.Position(xs[0].IntersectionValue))
+	want := tuples.VectorNew(0, 1, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestHeightfieldIntersectSlope(t *testing.T) {
+	h, err := HeightfieldNew(heightGrid(5, 5, func(x, z float64) float64 { return x }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rays.RayNew(tuples.PointNew(0.25, 2, 0.5), tuples.VectorNew(0, -1, 0))
+	xs := h.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 1.75) {
+		t.Errorf("got %v want %v", xs[0].IntersectionValue, 1.75)
+	}
+	got := h.NormalAt(r.Position(xs[0].IntersectionValue))
+	want := tuples.VectorNew(-math.Sqrt2/2, math.Sqrt2/2, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// a ray through a single peak along the edge between cells enters and leaves
+// it once each, even though neighboring cells share the edge
+func TestHeightfieldIntersectPeak(t *testing.T) {
+	heights := heightGrid(3, 3, func(x, z float64) float64 { return 0 })
+	heights[1][1] = 1
+	h, err := HeightfieldNew(heights)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := h.Intersect(rays.RayNew(tuples.PointNew(-1, 0.5, 0.5), tuples.VectorNew(1, 0, 0)))
+	want := []float64{1.25, 1.75}
+	if len(xs) != len(want) {
+		t.Fatalf("got %d want %d", len(xs), len(want))
+	}
+	for i, x := range xs {
+		if !tuples.FloatEqual(x.IntersectionValue, want[i]) {
+			t.Errorf("got %v want %v", x.IntersectionValue, want[i])
+		}
+	}
+	// passing over the top misses
+	xs = h.Intersect(rays.RayNew(tuples.PointNew(-1, 1.5, 0.5), tuples.VectorNew(1, 0, 0)))
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+// walking the grid should find the same hits as testing every cell
+func TestHeightfieldIntersectMatchesEveryCell(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	heights := heightGrid(9, 7, func(x, z float64) float64 { return rng.Float64() })
+	h, err := HeightfieldNew(heights, transformations.ScalingNew(10, 2, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hits := 0
+	for n := 0; n < 200; n++ {
+		origin := tuples.PointNew(rng.Float64()*30-10, rng.Float64()*4-1, rng.Float64()*30-10)
+		target := tuples.PointNew(rng.Float64()*10, rng.Float64()*2, rng.Float64()*10)
+		r := rays.RayNew(origin, target.Subtract(origin))
+		got := h.Intersect(r)
+		want := everyCell(h, r.Transform(inverseOf(h.Transform)))
+		hits += len(want)
+		if len(got) != len(want) {
+			t.Fatalf("ray %v: got %d want %d", r, len(got), len(want))
+		}
+		for i := range got {
+			if !tuples.FloatEqual(got[i].IntersectionValue, want[i].IntersectionValue) {
+				t.Errorf("ray %v: got %v want %v", r, got[i].IntersectionValue, want[i].IntersectionValue)
+			}
+		}
+	}
+	if hits == 0 {
+		t.Errorf("got no hits to compare")
+	}
+}
+
+// everyCell : intersect an object space ray with every cell, dropping
+// duplicates on shared edges
+func everyCell(h *Heightfield, r rays.Ray) []Intersection {
+	all := []Intersection{}
+	columns, rows := h.cells()
+	for i := 0; i < columns; i++ {
+		for j := 0; j < rows; j++ {
+			all = append(all, h.intersectCell(r, i, j)...)
+		}
+	}
+	IntersectionSort(all)
+	unique := []Intersection{}
+	for _, x := range all {
+		if n := len(unique); n > 0 && tuples.FloatEqual(unique[n-1].IntersectionValue, x.IntersectionValue) {
+			continue
+		}
+		unique = append(unique, x)
+	}
+	return unique
+}
+
+func TestHeightfieldFromCanvas(t *testing.T) {
+	c := canvas.CanvasNew(2, 3)
+	c.SetPixel(1, 2, tuples.ColorNew(0.5, 0.5, 0.5))
+	c.SetPixel(0, 1, tuples.ColorNew(0.3, 0.6, 0.9))
+	h, err := HeightfieldFromCanvas(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Heights) != 2 || len(h.Heights[0]) != 3 {
+		t.Fatalf("got %dx%d want %dx%d", len(h.Heights), len(h.Heights[0]), 2, 3)
+	}
+	if !tuples.FloatEqual(h.Heights[1][2], 0.5) || !tuples.FloatEqual(h.Heights[0][1], 0.6) {
+		t.Errorf("got %v", h.Heights)
+	}
+	got := h.Bounds()
+	want := BoundingBoxNew(tuples.PointNew(0, 0, 0), tuples.PointNew(1, 0.6, 1))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestHeightfieldNewInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		heights [][]float64
+		want    string
+	}{
+		{"empty", [][]float64{}, "heightfield needs at least 2x2 heights, got 0x0"},
+		{"one column", [][]float64{{0, 0, 0}}, "heightfield needs at least 2x2 heights, got 1x3"},
+		{"one row", [][]float64{{0}, {0}}, "heightfield needs at least 2x2 heights, got 2x1"},
+		{"ragged", [][]float64{{0, 0}, {0}}, "heightfield column 1 has 1 heights, want 2"},
+		{"ragged longer", [][]float64{{0, 0}, {0, 0}, {0, 0, 0}}, "heightfield column 2 has 3 heights, want 2"},
+	}
+	for _, tt := range tests {
+		h, err := HeightfieldNew(tt.heights)
+		if err == nil {
+			t.Fatalf("%s: got %v want error %q", tt.name, h, tt.want)
+		}
+		if err.Error() != tt.want {
+			t.Errorf("%s: got %q want %q", tt.name, err.Error(), tt.want)
+		}
+	}
+	_, err := HeightfieldFromCanvas(canvas.CanvasNew(1, 5))
+	if err == nil {
+		t.Errorf("got no error for a 1x5 image")
+	}
+}
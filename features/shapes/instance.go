@@ -0,0 +1,170 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Instance : a Shape, a placement of a shared shape with its own transform
+//
+// many instances can share one child, such as a mesh that has already been
+// divided into a BVH, without copying it. the child is never told about the
+// instance, so it must not be added to a group or CSG of its own. a non-nil
+// Material replaces the materials of the whole child. like groups, instances
+// are handled by pointer
+type Instance struct {
+	Child     Shape
+	Transform *mat.Dense
+	Material  *materials.Material
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// InstanceNew : instance constructor
+//
+// using variadic function to make transform optional
+func InstanceNew(child Shape, transform ...*mat.Dense) *Instance {
+	i := &Instance{Child: child, Transform: transformations.IdentityNew(4)}
+	if len(transform) > 0 {
+		i.Transform = transform[0]
+	}
+	i.id = shapeIDNew()
+	i.inverse = inverseCacheNew(i.Transform)
+	return i
+}
+
+// Intersect instance with ray
+//
+// the ray is converted to the instance's object space and intersected with
+// the child. each intersection is wrapped, so that normals and materials of
+// the hit account for the instance
+func (i *Instance) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(i.inverseTransform())
+	intersections := []Intersection{}
+	if !i.Bounds().Intersects(r) {
+		return intersections
+	}
+	for _, x := range i.Child.Intersect(r) {
+		x.Shape = instanceHit{i, x.Shape}
+		intersections = append(intersections, x)
+	}
+	return intersections
+}
+
+// NormalAt : instances have no surface of their own
+//
+// intersections always refer to a hit inside the child, so this should never
+// be called
+func (i *Instance) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	panic("NormalAt called on an instance, call it on the intersected shape instead")
+}
+
+// Bounds : the bounds of the child, in the instance's object space
+func (i *Instance) Bounds() BoundingBox {
+	return ParentSpaceBounds(i.Child)
+}
+
+// GetMaterial : get the replacement material of the instance, or the default
+// if it has none
+func (i *Instance) GetMaterial() materials.Material {
+	if i.Material != nil {
+		return *i.Material
+	}
+	return materials.MaterialNew()
+}
+
+// GetTransform : get the transform of the instance
+func (i *Instance) GetTransform() *mat.Dense {
+	return i.Transform
+}
+
+func (i *Instance) inverseTransform() *mat.Dense {
+	return i.inverse.of(i.Transform)
+}
+
+func (i *Instance) getID() uint64 {
+	return i.id
+}
+
+// GetParent : get the group or CSG containing the instance, if any
+func (i *Instance) GetParent() Shape {
+	return i.Parent
+}
+
+func (i *Instance) setParent(parent Shape) Shape {
+	i.Parent = parent
+	return i
+}
+
+// instanceHit : the shape recorded in an intersection with an instance
+//
+// it stands in for the shape hit inside the child, making that shape's
+// parent chain continue up through the instance. two instances of the same
+// child give different hits, so refraction and CSG can tell them apart
+type instanceHit struct {
+	instance *Instance
+	shape    Shape
+}
+
+// Intersect : hits only appear in intersections, so this should never be
+// called
+func (h instanceHit) Intersect(r rays.Ray) []Intersection {
+	panic("Intersect called on an instance hit, call it on the instance instead")
+}
+
+// NormalAt : the normal of the shape that was hit, as if the child were placed
+// by the instance
+func (h instanceHit) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	// the child sees the instance's object space as its world space
+	childPoint := WorldToObject(h.instance, worldPoint)
+	childHit := []Intersection{}
+	for _, x := range hit {
+		x.Shape = h.shape
+		childHit = append(childHit, x)
+	}
+	return NormalToWorld(h.instance, h.shape.NormalAt(childPoint, childHit...))
+}
+
+// GetMaterial : the instance's replacement material, or the hit shape's own
+func (h instanceHit) GetMaterial() materials.Material {
+	if h.instance.Material != nil {
+		return *h.instance.Material
+	}
+	return h.shape.GetMaterial()
+}
+
+// GetTransform : get the transform of the shape that was hit
+func (h instanceHit) GetTransform() *mat.Dense {
+	return h.shape.GetTransform()
+}
+
+func (h instanceHit) inverseTransform() *mat.Dense {
+	return h.shape.inverseTransform()
+}
+
+func (h instanceHit) getID() uint64 {
+	return h.shape.getID()
+}
+
+// Bounds : the object space bounds of the shape that was hit
+func (h instanceHit) Bounds() BoundingBox {
+	return h.shape.Bounds()
+}
+
+// GetParent : the hit shape's parent, seen through the instance. the top of
+// the child has the instance itself as its parent
+func (h instanceHit) GetParent() Shape {
+	if parent := h.shape.GetParent(); parent != nil {
+		return instanceHit{h.instance, parent}
+	}
+	return h.instance
+}
+
+func (h instanceHit) setParent(parent Shape) Shape {
+	return h
+}
@@ -0,0 +1,164 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestInstanceIntersectShared(t *testing.T) {
+	s := SphereNew()
+	left := InstanceNew(s, transformations.TranslationNew(-3, 0, 0))
+	right := InstanceNew(s, transformations.TranslationNew(3, 0, 0))
+	r := rays.RayNew(tuples.PointNew(-3, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := left.Intersect(r)
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 4) || !tuples.FloatEqual(xs[1].IntersectionValue, 6) {
+		t.Errorf("got %v want %v", xs, []float64{4, 6})
+	}
+	if len(right.Intersect(r)) != 0 {
+		t.Errorf("got %d want %d", len(right.Intersect(r)), 0)
+	}
+	// the same sphere hit through two instances is two different shapes
+	other := right.Intersect(rays.RayNew(tuples.PointNew(3, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(other) != 2 {
+		t.Fatalf("got %d want %d", len(other), 2)
+	}
+	if xs[0].Shape == other[0].Shape {
+		t.Errorf("got equal hit shapes for different instances")
+	}
+	if xs[0].Shape != xs[1].Shape {
+		t.Errorf("got different hit shapes for one instance")
+	}
+	// the shared sphere is untouched
+	if s.GetParent() != nil {
+		t.Errorf("got %v want %v", s.GetParent(), nil)
+	}
+}
+
+// an instance of a transformed shape should shade like a group holding the
+// same shape with the same transform
+func TestInstanceNormalAtMatchesGroup(t *testing.T) {
+	transform := transformations.ChainTransform(transformations.TranslationNew(1, 2, 3),
+		transformations.RotationYNew(math.Pi/3), transformations.ScalingNew(1, 2, 1))
+	child := SphereNew(transformations.ScalingNew(2, 1, 1))
+	instance := InstanceNew(child, transform)
+	g := GroupNew(transform)
+	g.AddChild(child)
+	r := rays.RayNew(tuples.PointNew(-5, 1, 0), tuples.VectorNew(1, 0.2, 0.6))
+	got := instance.Intersect(r)
+	want := g.Intersect(r)
+	if len(got) != 2 || len(want) != 2 {
+		t.Fatalf("got %d want %d", len(got), len(want))
+	}
+	for i := range got {
+		if !tuples.FloatEqual(got[i].IntersectionValue, want[i].IntersectionValue) {
+			t.Errorf("got %v want %v", got[i].IntersectionValue, want[i].IntersectionValue)
+		}
+		point := r.Position(got[i].IntersectionValue)
+		gotNormal := got[i].Shape.NormalAt(point, got[i])
+		wantNormal := want[i].Shape.NormalAt(point, want[i])
+		if !gotNormal.Equal(wantNormal) {
+			t.Errorf("got %v want %v", gotNormal, wantNormal)
+		}
+	}
+}
+
+// smooth triangles read u and v from the hit, which must reach them unwrapped
+func TestInstanceNormalAtSmoothTriangle(t *testing.T) {
+	tri := SmoothTriangleNew(
+		tuples.PointNew(0, 1, 0), tuples.PointNew(-1, 0, 0), tuples.PointNew(1, 0, 0),
+		tuples.VectorNew(0, 1, 0), tuples.VectorNew(-1, 0, 0), tuples.VectorNew(1, 0, 0))
+	instance := InstanceNew(tri, transformations.TranslationNew(0, 0, 5))
+	xs := instance.Intersect(rays.RayNew(tuples.PointNew(-0.2, 0.3, 0), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	got := xs[0].Shape.NormalAt(tuples.PointNew(0, 0, 5), xs[0])
+	want := tuples.VectorNew(-0.5547, 0.83205, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestInstanceMaterial(t *testing.T) {
+	s := SphereNew()
+	s.Material.Color = tuples.ColorNew(1, 0, 0)
+	plain := InstanceNew(s)
+	blue := InstanceNew(s)
+	m := materials.MaterialNew()
+	m.Color = tuples.ColorNew(0, 0, 1)
+	blue.Material = &m
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	got := plain.Intersect(r)[0].Shape.GetMaterial().Color
+	want := tuples.ColorNew(1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = blue.Intersect(r)[0].Shape.GetMaterial().Color
+	want = tuples.ColorNew(0, 0, 1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// patterns are looked up in the space of the hit shape, through the instance
+func TestInstancePatternSpace(t *testing.T) {
+	s := SphereNew(transformations.ScalingNew(2, 2, 2))
+	g := GroupNew(transformations.TranslationNew(0, 1, 0))
+	g.AddChild(s)
+	instance := InstanceNew(g, transformations.TranslationNew(5, 0, 0))
+	xs := instance.Intersect(rays.RayNew(tuples.PointNew(5, 1, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	got := WorldToObject(xs[0].Shape, tuples.PointNew(7, 1, 0))
+	want := tuples.PointNew(1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestInstanceInGroupAndCSG(t *testing.T) {
+	s := SphereNew()
+	left := InstanceNew(s)
+	right := InstanceNew(s, transformations.TranslationNew(0, 0, 0.5))
+	c := CSGNew(CSGUnion, left, right)
+	xs := c.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 4) || !tuples.FloatEqual(xs[1].IntersectionValue, 6.5) {
+		t.Errorf("got %v want %v", xs, []float64{4, 6.5})
+	}
+	g := GroupNew(transformations.ScalingNew(2, 2, 2))
+	g.AddChild(left)
+	if left.GetParent() != Shape(g) {
+		t.Errorf("got %v want %v", left.GetParent(), g)
+	}
+	xs = g.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	got := xs[0].Shape.NormalAt(tuples.PointNew(0, 0, -2), xs[0])
+	want := tuples.VectorNew(0, 0, -1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestInstanceBounds(t *testing.T) {
+	instance := InstanceNew(SphereNew(transformations.TranslationNew(1, 0, 0)), transformations.ScalingNew(2, 2, 2))
+	got := instance.Bounds()
+	want := BoundingBoxNew(tuples.PointNew(0, -1, -1), tuples.PointNew(2, 1, 1))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = ParentSpaceBounds(instance)
+	want = BoundingBoxNew(tuples.PointNew(0, -2, -2), tuples.PointNew(4, 2, 2))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
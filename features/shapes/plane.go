@@ -0,0 +1,85 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Plane : a Shape, the infinite xz plane in object space
+type Plane struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// PlaneNew : plane constructor
+//
+// using variadic function to make transform optional
+func PlaneNew(transform ...*mat.Dense) Plane {
+	p := Plane{transformations.IdentityNew(4), materials.MaterialNew(), nil, 0, nil}
+	if len(transform) > 0 {
+		p.Transform = transform[0]
+	}
+	p.id = shapeIDNew()
+	p.inverse = inverseCacheNew(p.Transform)
+	return p
+}
+
+// Intersect plane with ray
+func (p Plane) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(p.inverseTransform())
+	// a ray parallel to the plane (or coplanar with it) never hits it
+	if math.Abs(r.Direction.Y) < tuples.EPSILON {
+		return []Intersection{}
+	}
+	// the plane sits at y = 0, so find where the ray's y reaches 0
+	t := -r.Origin.Y / r.Direction.Y
+	return []Intersection{IntersectionNew(t, p)}
+}
+
+// NormalAt : the plane has the same normal everywhere
+func (p Plane) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	return NormalToWorld(p, tuples.VectorNew(0, 1, 0))
+}
+
+// GetMaterial : get the material of the plane
+func (p Plane) GetMaterial() materials.Material {
+	return p.Material
+}
+
+// GetTransform : get the transform of the plane
+func (p Plane) GetTransform() *mat.Dense {
+	return p.Transform
+}
+
+func (p Plane) inverseTransform() *mat.Dense {
+	return p.inverse.of(p.Transform)
+}
+
+func (p Plane) getID() uint64 {
+	return p.id
+}
+
+// GetParent : get the group or CSG containing the plane, if any
+func (p Plane) GetParent() Shape {
+	return p.Parent
+}
+
+func (p Plane) setParent(parent Shape) Shape {
+	p.Parent = parent
+	return p
+}
+
+// Bounds : the plane is infinite in x and z
+func (p Plane) Bounds() BoundingBox {
+	return BoundingBoxNew(
+		tuples.PointNew(math.Inf(-1), 0, math.Inf(-1)),
+		tuples.PointNew(math.Inf(1), 0, math.Inf(1)))
+}
@@ -0,0 +1,78 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestPlaneNormalAt(t *testing.T) {
+	p := PlaneNew()
+	want := tuples.VectorNew(0, 1, 0)
+	for _, point := range []tuples.Tuple{
+		tuples.PointNew(0, 0, 0),
+		tuples.PointNew(10, 0, -10),
+		tuples.PointNew(-5, 0, 150),
+	} {
+		got := p.NormalAt(point)
+		if !got.Equal(want) {
+			t.Errorf("got %v want %v", got, want)
+		}
+	}
+}
+
+func TestTransformedPlaneNormalAt(t *testing.T) {
+	p := PlaneNew(transformations.RotationZNew(math.Pi / 2))
+	got := p.NormalAt(tuples.PointNew(0, 0, 0))
+	want := tuples.VectorNew(-1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestPlaneIntersectParallel(t *testing.T) {
+	p := PlaneNew()
+	r := rays.RayNew(tuples.PointNew(0, 10, 0), tuples.VectorNew(0, 0, 1))
+	xs := p.Intersect(r)
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestPlaneIntersectCoplanar(t *testing.T) {
+	p := PlaneNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1))
+	xs := p.Intersect(r)
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestPlaneIntersectFromAbove(t *testing.T) {
+	p := PlaneNew()
+	r := rays.RayNew(tuples.PointNew(0, 1, 0), tuples.VectorNew(0, -1, 0))
+	xs := p.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 1) {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 1.0)
+	}
+	if xs[0].Shape != p {
+		t.Errorf("got %v want %v", xs[0].Shape, p)
+	}
+}
+
+func TestPlaneIntersectFromBelow(t *testing.T) {
+	p := PlaneNew(transformations.TranslationNew(0, -1, 0))
+	r := rays.RayNew(tuples.PointNew(0, -2, 0), tuples.VectorNew(0, 1, 0))
+	xs := p.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 1) {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 1.0)
+	}
+}
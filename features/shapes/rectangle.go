@@ -0,0 +1,112 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Rectangle : a Shape, a flat rectangle in object space
+//
+// the rectangle spans Corner + u*EdgeU + v*EdgeV for u and v from 0 to 1, and
+// intersections record that u and v. edges that aren't perpendicular make a
+// parallelogram, which works the same way. the normal is EdgeV x EdgeU, so
+// edges along +x and +z make a rectangle facing +y
+type Rectangle struct {
+	Corner       tuples.Tuple
+	EdgeU, EdgeV tuples.Tuple
+	Normal       tuples.Tuple
+	Transform    *mat.Dense
+	Material     materials.Material
+	Parent       Shape
+	id           uint64
+	inverse      *inverseCache
+}
+
+// RectangleNew : rectangle constructor
+//
+// using variadic function to make transform optional
+func RectangleNew(corner, edgeU, edgeV tuples.Tuple, transform ...*mat.Dense) Rectangle {
+	rect := Rectangle{Corner: corner, EdgeU: edgeU, EdgeV: edgeV}
+	rect.Normal = edgeV.CrossProduct(edgeU).Normalize()
+	rect.Transform = transformations.IdentityNew(4)
+	if len(transform) > 0 {
+		rect.Transform = transform[0]
+	}
+	rect.Material = materials.MaterialNew()
+	rect.id = shapeIDNew()
+	rect.inverse = inverseCacheNew(rect.Transform)
+	return rect
+}
+
+// Intersect rectangle with ray
+func (rect Rectangle) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(rect.inverseTransform())
+	denominator := rect.Normal.DotProduct(r.Direction)
+	// a ray parallel to the rectangle (or coplanar with it) never hits it
+	if math.Abs(denominator) < tuples.EPSILON {
+		return []Intersection{}
+	}
+	t := rect.Normal.DotProduct(rect.Corner.Subtract(r.Origin)) / denominator
+	// solve relative = u*EdgeU + v*EdgeV. projecting onto each edge alone
+	// would only be right for perpendicular edges
+	relative := r.Position(t).Subtract(rect.Corner)
+	uu := rect.EdgeU.DotProduct(rect.EdgeU)
+	uv := rect.EdgeU.DotProduct(rect.EdgeV)
+	vv := rect.EdgeV.DotProduct(rect.EdgeV)
+	ru := relative.DotProduct(rect.EdgeU)
+	rv := relative.DotProduct(rect.EdgeV)
+	determinant := uu*vv - uv*uv
+	u := (vv*ru - uv*rv) / determinant
+	v := (uu*rv - uv*ru) / determinant
+	if u < 0 || u > 1 || v < 0 || v > 1 {
+		return []Intersection{}
+	}
+	return []Intersection{IntersectionWithUVNew(t, rect, u, v)}
+}
+
+// NormalAt : the rectangle has the same normal everywhere
+func (rect Rectangle) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	return NormalToWorld(rect, rect.Normal)
+}
+
+// Bounds : the object space bounds of the rectangle
+func (rect Rectangle) Bounds() BoundingBox {
+	return BoundingBoxNew(
+		rect.Corner,
+		rect.Corner.Add(rect.EdgeU),
+		rect.Corner.Add(rect.EdgeV),
+		rect.Corner.Add(rect.EdgeU).Add(rect.EdgeV))
+}
+
+// GetMaterial : get the material of the rectangle
+func (rect Rectangle) GetMaterial() materials.Material {
+	return rect.Material
+}
+
+// GetTransform : get the transform of the rectangle
+func (rect Rectangle) GetTransform() *mat.Dense {
+	return rect.Transform
+}
+
+func (rect Rectangle) inverseTransform() *mat.Dense {
+	return rect.inverse.of(rect.Transform)
+}
+
+func (rect Rectangle) getID() uint64 {
+	return rect.id
+}
+
+// GetParent : get the group or CSG containing the rectangle, if any
+func (rect Rectangle) GetParent() Shape {
+	return rect.Parent
+}
+
+func (rect Rectangle) setParent(parent Shape) Shape {
+	rect.Parent = parent
+	return rect
+}
@@ -0,0 +1,107 @@
+package shapes
+
+import (
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func testRectangle() Rectangle {
+	return RectangleNew(tuples.PointNew(-1, 0, -2), tuples.VectorNew(2, 0, 0), tuples.VectorNew(0, 0, 4))
+}
+
+func TestRectangleNormalAt(t *testing.T) {
+	rect := testRectangle()
+	got := rect.NormalAt(tuples.PointNew(0, 0, 0))
+	want := tuples.VectorNew(0, 1, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestRectangleIntersect(t *testing.T) {
+	rect := testRectangle()
+	tests := []struct {
+		name   string
+		origin tuples.Tuple
+		dir    tuples.Tuple
+		count  int
+	}{
+		{"through the middle", tuples.PointNew(0, 1, 0), tuples.VectorNew(0, -1, 0), 1},
+		{"from below", tuples.PointNew(0.5, -1, 1), tuples.VectorNew(0, 1, 0), 1},
+		{"past the u edge", tuples.PointNew(1.5, 1, 0), tuples.VectorNew(0, -1, 0), 0},
+		{"past the v edge", tuples.PointNew(0, 1, -2.5), tuples.VectorNew(0, -1, 0), 0},
+		{"parallel", tuples.PointNew(0, 1, 0), tuples.VectorNew(1, 0, 0), 0},
+	}
+	for _, tt := range tests {
+		r := rays.RayNew(tt.origin, tt.dir)
+		xs := rect.Intersect(r)
+		if len(xs) != tt.count {
+			t.Errorf("%s: got %d want %d", tt.name, len(xs), tt.count)
+		}
+	}
+}
+
+func TestRectangleIntersectUV(t *testing.T) {
+	rect := testRectangle()
+	r := rays.RayNew(tuples.PointNew(0.5, 2, -1), tuples.VectorNew(0, -1, 0))
+	xs := rect.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 2) {
+		t.Errorf("got %v want %v", xs[0].IntersectionValue, 2)
+	}
+	if !tuples.FloatEqual(xs[0].U, 0.75) || !tuples.FloatEqual(xs[0].V, 0.25) {
+		t.Errorf("got (%v, %v) want (%v, %v)", xs[0].U, xs[0].V, 0.75, 0.25)
+	}
+}
+
+// skewed edges make a parallelogram, with u and v measured along each edge
+func TestParallelogramIntersectUV(t *testing.T) {
+	rect := RectangleNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(2, 0, 0), tuples.VectorNew(1, 0, 1))
+	tests := []struct {
+		name  string
+		x, z  float64
+		count int
+		u, v  float64
+	}{
+		{"inside", 2, 0.5, 1, 0.75, 0.5},
+		{"far corner", 3, 1, 1, 1, 1},
+		{"inside the bounds, outside the parallelogram", 0.2, 0.9, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		xs := rect.Intersect(rays.RayNew(tuples.PointNew(tt.x, 1, tt.z), tuples.VectorNew(0, -1, 0)))
+		if len(xs) != tt.count {
+			t.Errorf("%s: got %d want %d", tt.name, len(xs), tt.count)
+			continue
+		}
+		if tt.count > 0 && (!tuples.FloatEqual(xs[0].U, tt.u) || !tuples.FloatEqual(xs[0].V, tt.v)) {
+			t.Errorf("%s: got (%v, %v) want (%v, %v)", tt.name, xs[0].U, xs[0].V, tt.u, tt.v)
+		}
+	}
+}
+
+func TestTransformedRectangleIntersect(t *testing.T) {
+	rect := RectangleNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(1, 0, 0), tuples.VectorNew(0, 0, 1),
+		transformations.TranslationNew(0, 0, 5))
+	r := rays.RayNew(tuples.PointNew(0.5, 1, 5.5), tuples.VectorNew(0, -1, 0))
+	xs := rect.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	r = rays.RayNew(tuples.PointNew(0.5, 1, 0.5), tuples.VectorNew(0, -1, 0))
+	xs = rect.Intersect(r)
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+}
+
+func TestRectangleBounds(t *testing.T) {
+	got := testRectangle().Bounds()
+	want := BoundingBoxNew(tuples.PointNew(-1, 0, -2), tuples.PointNew(1, 0, 2))
+	if !got.Min.Equal(want.Min) || !got.Max.Equal(want.Max) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/sdf"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// sdfMinStep : the smallest distance a march moves at once, so that it can
+// step across a surface instead of creeping up on it
+var sdfMinStep = 0.0001
+
+// sdfBisections : how many times a crossing is halved to find the surface
+var sdfBisections = 40
+
+// sdfNormalDelta : the offset used for the central differences in NormalAt
+var sdfNormalDelta = 0.0001
+
+// SDFShape : a Shape, the surface where a signed distance function is zero
+//
+// SDF shapes are handled by pointer, because a Go func cannot be compared and
+// intersections need to compare shapes. the distance function is only
+// marched inside Box, which must enclose the whole surface
+type SDFShape struct {
+	Transform *mat.Dense
+	Material  materials.Material
+	Distance  sdf.Func
+	Box       BoundingBox
+	// MaxSteps : give up marching after this many steps
+	MaxSteps int
+	// StepScale : the fraction of the distance moved each step, lower this for
+	// functions that overestimate distances, like sdf.Twist
+	StepScale float64
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
+}
+
+// SDFShapeNew : SDF shape constructor
+//
+// using variadic function to make transform optional
+func SDFShapeNew(distance sdf.Func, box BoundingBox, transform ...*mat.Dense) *SDFShape {
+	s := &SDFShape{
+		Transform: transformations.IdentityNew(4),
+		Material:  materials.MaterialNew(),
+		Distance:  distance,
+		Box:       box,
+		MaxSteps:  1000,
+		StepScale: 1,
+	}
+	if len(transform) > 0 {
+		s.Transform = transform[0]
+	}
+	s.id = shapeIDNew()
+	s.inverse = inverseCacheNew(s.Transform)
+	return s
+}
+
+// Intersect SDF shape with ray
+//
+// “sphere tracing”: the distance function says how far the ray can safely
+// move without passing through the surface. the ray is marched through the
+// box, and every point where the distance changes sign is refined by
+// bisection into an intersection
+func (s *SDFShape) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(s.inverseTransform())
+	intersections := []Intersection{}
+	xtmin, xtmax := checkAxis(r.Origin.X, r.Direction.X, s.Box.Min.X, s.Box.Max.X)
+	ytmin, ytmax := checkAxis(r.Origin.Y, r.Direction.Y, s.Box.Min.Y, s.Box.Max.Y)
+	ztmin, ztmax := checkAxis(r.Origin.Z, r.Direction.Z, s.Box.Min.Z, s.Box.Max.Z)
+	tmin := math.Max(xtmin, math.Max(ytmin, ztmin))
+	tmax := math.Min(xtmax, math.Min(ytmax, ztmax))
+	// the ray misses the box, or the box is unbounded
+	if tmin > tmax || math.IsInf(tmin, 0) || math.IsInf(tmax, 0) {
+		return intersections
+	}
+	// distances are measured along the surface, t along the ray
+	speed := r.Direction.Magnitude()
+	t := tmin
+	d := s.Distance(r.Position(t))
+	for step := 0; step < s.MaxSteps && t < tmax; step++ {
+		next := math.Min(t+math.Max(math.Abs(d)*s.StepScale, sdfMinStep)/speed, tmax)
+		nextD := s.Distance(r.Position(next))
+		if (d < 0) != (nextD < 0) {
+			intersections = append(intersections, IntersectionNew(s.refine(r, t, next, d), s))
+		}
+		t, d = next, nextD
+	}
+	return intersections
+}
+
+// refine : bisect the interval of the ray that crosses the surface
+func (s *SDFShape) refine(r rays.Ray, low, high, lowD float64) float64 {
+	for i := 0; i < sdfBisections; i++ {
+		mid := (low + high) / 2
+		midD := s.Distance(r.Position(mid))
+		if (midD < 0) == (lowD < 0) {
+			low, lowD = mid, midD
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// NormalAt : the normal is the gradient of the distance function, estimated
+// by central differences
+func (s *SDFShape) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(s, worldPoint)
+	h := sdfNormalDelta
+	dx := s.Distance(tuples.PointNew(p.X+h, p.Y, p.Z)) - s.Distance(tuples.PointNew(p.X-h, p.Y, p.Z))
+	dy := s.Distance(tuples.PointNew(p.X, p.Y+h, p.Z)) - s.Distance(tuples.PointNew(p.X, p.Y-h, p.Z))
+	dz := s.Distance(tuples.PointNew(p.X, p.Y, p.Z+h)) - s.Distance(tuples.PointNew(p.X, p.Y, p.Z-h))
+	return NormalToWorld(s, tuples.VectorNew(dx, dy, dz))
+}
+
+// Bounds : the object space bounds of the SDF shape, its marching box
+func (s *SDFShape) Bounds() BoundingBox {
+	return s.Box
+}
+
+// GetMaterial : get the material of the SDF shape
+func (s *SDFShape) GetMaterial() materials.Material {
+	return s.Material
+}
+
+// GetTransform : get the transform of the SDF shape
+func (s *SDFShape) GetTransform() *mat.Dense {
+	return s.Transform
+}
+
+func (s *SDFShape) inverseTransform() *mat.Dense {
+	return s.inverse.of(s.Transform)
+}
+
+func (s *SDFShape) getID() uint64 {
+	return s.id
+}
+
+// GetParent : get the group or CSG containing the SDF shape, if any
+func (s *SDFShape) GetParent() Shape {
+	return s.Parent
+}
+
+func (s *SDFShape) setParent(parent Shape) Shape {
+	s.Parent = parent
+	return s
+}
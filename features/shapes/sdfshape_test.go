@@ -0,0 +1,104 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/sdf"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func unitBox() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(-1.5, -1.5, -1.5), tuples.PointNew(1.5, 1.5, 1.5))
+}
+
+// an SDF sphere should intersect like the analytic sphere
+func TestSDFShapeIntersectSphere(t *testing.T) {
+	s := SDFShapeNew(sdf.Sphere(1), unitBox())
+	tests := []struct {
+		origin tuples.Tuple
+		dir    tuples.Tuple
+		want   []float64
+	}{
+		{tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1), []float64{4, 6}},
+		{tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1), []float64{-1, 1}},
+		{tuples.PointNew(0, 0, 5), tuples.VectorNew(0, 0, 1), []float64{-6, -4}},
+		{tuples.PointNew(0, 2, -5), tuples.VectorNew(0, 0, 1), []float64{}},
+		{tuples.PointNew(0.5, 0, -5), tuples.VectorNew(0, 0, 2), []float64{2.5 - math.Sqrt(0.75)/2, 2.5 + math.Sqrt(0.75)/2}},
+	}
+	for _, tt := range tests {
+		xs := s.Intersect(rays.RayNew(tt.origin, tt.dir))
+		if len(xs) != len(tt.want) {
+			t.Fatalf("got %d want %d", len(xs), len(tt.want))
+		}
+		for i, x := range xs {
+			if !tuples.FloatEqual(x.IntersectionValue, tt.want[i]) {
+				t.Errorf("got %v want %v", x.IntersectionValue, tt.want[i])
+			}
+			if x.Shape != Shape(s) {
+				t.Errorf("got %v want %v", x.Shape, s)
+			}
+		}
+	}
+}
+
+func TestSDFShapeIntersectTransformed(t *testing.T) {
+	s := SDFShapeNew(sdf.Box(tuples.VectorNew(1, 1, 1)), unitBox(), transformations.ScalingNew(2, 2, 2))
+	xs := s.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 3) || !tuples.FloatEqual(xs[1].IntersectionValue, 7) {
+		t.Errorf("got %v want %v", xs, []float64{3, 7})
+	}
+}
+
+// subtracting a capsule through a sphere leaves a ring with four crossings
+func TestSDFShapeIntersectSubtraction(t *testing.T) {
+	hole := sdf.Capsule(tuples.PointNew(0, -2, 0), tuples.PointNew(0, 2, 0), 0.5)
+	s := SDFShapeNew(sdf.Subtraction(sdf.Sphere(1), hole), unitBox())
+	xs := s.Intersect(rays.RayNew(tuples.PointNew(-5, 0, 0), tuples.VectorNew(1, 0, 0)))
+	want := []float64{4, 4.5, 5.5, 6}
+	if len(xs) != len(want) {
+		t.Fatalf("got %d want %d", len(xs), len(want))
+	}
+	for i, x := range xs {
+		if !tuples.FloatEqual(x.IntersectionValue, want[i]) {
+			t.Errorf("got %v want %v", x.IntersectionValue, want[i])
+		}
+	}
+}
+
+func TestSDFShapeNormalAt(t *testing.T) {
+	s := SDFShapeNew(sdf.Sphere(1), unitBox())
+	v := math.Sqrt(3) / 3
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(1, 0, 0), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(0, -1, 0), tuples.VectorNew(0, -1, 0)},
+		{tuples.PointNew(v, v, v), tuples.VectorNew(v, v, v)},
+	}
+	for _, tt := range tests {
+		got := s.NormalAt(tt.point)
+		if !got.Equal(tt.want) {
+			t.Errorf("got %v want %v", got, tt.want)
+		}
+	}
+}
+
+func TestSDFShapeInGroup(t *testing.T) {
+	g := GroupNew(transformations.TranslationNew(5, 0, 0))
+	s := SDFShapeNew(sdf.Torus(1, 0.25), BoundingBoxNew(tuples.PointNew(-1.5, -0.5, -1.5), tuples.PointNew(1.5, 0.5, 1.5)))
+	g.AddChild(s)
+	if s.GetParent() != Shape(g) {
+		t.Errorf("got %v want %v", s.GetParent(), g)
+	}
+	got := s.NormalAt(tuples.PointNew(6.25, 0, 0))
+	want := tuples.VectorNew(1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
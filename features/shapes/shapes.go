@@ -4,75 +4,252 @@ import (
 	"errors"
 	"gonum.org/v1/gonum/mat"
 	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/patterns"
 	"sarim-tracer/features/rays"
+	"sarim-tracer/features/roots"
 	"sarim-tracer/features/transformations"
 	"sarim-tracer/features/tuples"
 	"sort"
+	"sync/atomic"
 )
 
 // Shape interface
+//
+// a shape may belong to a Group or CSG, in which case its transform is
+// relative to the parent's object space rather than world space
 type Shape interface {
 	Intersect(r rays.Ray) []Intersection
+	// NormalAt : the hit is optional, only shapes that interpolate across their
+	// surface (like smooth triangles) need its u and v
+	NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple
+	GetMaterial() materials.Material
+	GetTransform() *mat.Dense
+	// Bounds : the bounding box of the shape in its own object space
+	Bounds() BoundingBox
+	GetParent() Shape
+	// setParent : return the shape with its parent set, used when a shape is
+	// added to a Group or CSG
+	setParent(parent Shape) Shape
+	// inverseTransform : the inverse of GetTransform, without inverting it
+	// again on every call
+	inverseTransform() *mat.Dense
+	// getID : the id given to the shape by its constructor, shared by its
+	// copies, or zero if it was built without one
+	getID() uint64
+}
+
+// WorldToObject : convert a world space point to the object space of a shape
+//
+// the point is first converted to the space of each parent, outermost first
+func WorldToObject(s Shape, worldPoint tuples.Tuple) tuples.Tuple {
+	if parent := s.GetParent(); parent != nil {
+		worldPoint = WorldToObject(parent, worldPoint)
+	}
+	return worldPoint.Transform(s.inverseTransform())
+}
+
+// NormalToWorld : convert an object space normal to a world space normal
+//
+// the normal is multiplied by the inverse transpose of the transform, then
+// converted by each parent in turn, innermost first
+func NormalToWorld(s Shape, objectNormal tuples.Tuple) tuples.Tuple {
+	inverseTranspose := mat.DenseCopyOf(s.inverseTransform().T())
+	normal := objectNormal.Transform(inverseTranspose)
+	// translation can leave junk in w, so force it back to a vector
+	normal.W = 0
+	normal = normal.Normalize()
+	if parent := s.GetParent(); parent != nil {
+		normal = NormalToWorld(parent, normal)
+	}
+	return normal
+}
+
+// PatternAtShape : evaluate a pattern at a world space point on a shape
+//
+// the point is converted to object space, then to pattern space
+func PatternAtShape(p patterns.Pattern, s Shape, worldPoint tuples.Tuple) tuples.Tuple {
+	return patterns.PatternAtObject(p, WorldToObject(s, worldPoint))
 }
 
 // Sphere : a Shape
 type Sphere struct {
 	Transform *mat.Dense
+	Material  materials.Material
+	Parent    Shape
+	id        uint64
+	inverse   *inverseCache
 }
 
 // SphereNew : sphere constructor
 //
 // using variadic function to make transform optional
 func SphereNew(transform ...*mat.Dense) Sphere {
-	if len(transform) == 0 {
-		return Sphere{transformations.IdentityNew(4)}
+	s := Sphere{transformations.IdentityNew(4), materials.MaterialNew(), nil, 0, nil}
+	if len(transform) > 0 {
+		s.Transform = transform[0]
 	}
-	return Sphere{transform[0]}
+	s.id = shapeIDNew()
+	s.inverse = inverseCacheNew(s.Transform)
+	return s
+}
+
+// GetMaterial : get the material of the sphere
+func (s Sphere) GetMaterial() materials.Material {
+	return s.Material
 }
 
 // Intersect sphere with ray
 func (s Sphere) Intersect(r rays.Ray) []Intersection {
 	// inverse transform the ray
-	transform := transformations.IdentityNew(4)
-	transform.Inverse(s.Transform)
-	r = r.Transform(transform)
+	r = r.Transform(s.inverseTransform())
 	// assume unit sphere at global origin
 	// create ray from sphere center to ray origin
 	sphereToRay := r.Origin.Subtract(tuples.PointNew(0, 0, 0))
-	// compute discriminant
+	// solve |origin + t * direction|^2 = 1 for t
 	a := r.Direction.DotProduct(r.Direction)
 	b := 2.0 * r.Direction.DotProduct(sphereToRay)
 	c := sphereToRay.DotProduct(sphereToRay) - 1.0
-	discriminant := (b * b) - (4 * a * c)
+	ts := roots.SolveQuadratic(a, b, c)
 	// ray misses the sphere
-	if discriminant < 0 {
+	if len(ts) < 2 {
 		return []Intersection{}
 	}
 	// ray is either crossing, tangent, inside or in front of the sphere
-	t1 := (-b - math.Sqrt(discriminant)) / (2.0 * a)
-	t2 := (-b + math.Sqrt(discriminant)) / (2.0 * a)
-	return []Intersection{IntersectionNew(t1, s),
-		IntersectionNew(t2, s)}
+	return []Intersection{IntersectionNew(ts[0], s),
+		IntersectionNew(ts[1], s)}
+}
+
+// GetTransform : get the transform of the sphere
+func (s Sphere) GetTransform() *mat.Dense {
+	return s.Transform
+}
+
+func (s Sphere) inverseTransform() *mat.Dense {
+	return s.inverse.of(s.Transform)
+}
+
+func (s Sphere) getID() uint64 {
+	return s.id
+}
+
+// GetParent : get the group or CSG containing the sphere, if any
+func (s Sphere) GetParent() Shape {
+	return s.Parent
+}
+
+func (s Sphere) setParent(parent Shape) Shape {
+	s.Parent = parent
+	return s
+}
+
+// NormalAt : get the world space normal of the sphere at a world space point
+//
+// “...you’ll first convert the point from world space to object space, then
+// compute the normal in object space, and finally convert the normal back to
+// world space by multiplying it by the inverse transpose of the transform.”
+func (s Sphere) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	// convert the point to object space
+	objectPoint := WorldToObject(s, worldPoint)
+	// the object space normal points away from the center
+	objectNormal := objectPoint.Subtract(tuples.PointNew(0, 0, 0))
+	return NormalToWorld(s, objectNormal)
+}
+
+// inverseOf : invert a transform, leaving the original untouched
+func inverseOf(transform *mat.Dense) *mat.Dense {
+	inverse := transformations.IdentityNew(4)
+	inverse.Inverse(transform)
+	return inverse
+}
+
+// shapeCount : how many shapes have been built by a constructor
+var shapeCount uint64
+
+// shapeIDNew : a new id for a shape, different from every id before it
+//
+// shapes with the same fields look equal to ==, so the id is what tells them
+// apart
+func shapeIDNew() uint64 {
+	return atomic.AddUint64(&shapeCount, 1)
+}
+
+// sameShape : check if two shapes are copies of the same shape
+//
+// shapes are compared by id rather than with ==, which can't tell apart two
+// shapes built with the same fields, and panics on shapes that can't be
+// compared. only shapes built without a constructor fall back to ==
+func sameShape(a, b Shape) bool {
+	hitA, isHitA := a.(instanceHit)
+	hitB, isHitB := b.(instanceHit)
+	if isHitA || isHitB {
+		return isHitA && isHitB && hitA.instance == hitB.instance && sameShape(hitA.shape, hitB.shape)
+	}
+	if a.getID() != 0 || b.getID() != 0 {
+		return a.getID() == b.getID()
+	}
+	return a == b
+}
+
+// inverseCache : a copy of a shape's transform and its inverse
+//
+// shapes are copied freely, so the cache is shared by pointer. when the
+// transform is replaced or changed after the shape is built, the cache no
+// longer matches and is worked out again. a shape built without a
+// constructor has no cache, and inverts its transform on every call
+type inverseCache struct {
+	transform, inverse *mat.Dense
+}
+
+// inverseCacheNew : invert a transform once, keeping a copy to check against
+func inverseCacheNew(transform *mat.Dense) *inverseCache {
+	return &inverseCache{mat.DenseCopyOf(transform), inverseOf(transform)}
+}
+
+// of : the inverse of a transform, from the cache if the transform matches
+func (c *inverseCache) of(transform *mat.Dense) *mat.Dense {
+	if c == nil {
+		return inverseOf(transform)
+	}
+	if !mat.Equal(c.transform, transform) {
+		*c = *inverseCacheNew(transform)
+	}
+	return c.inverse
 }
 
 // Intersection : intersection result of ray with shape
+//
+// U and V locate the intersection on the surface of a triangle, disk or
+// rectangle, and are zero for other shapes
 type Intersection struct {
 	IntersectionValue float64
 	Shape             Shape
+	U, V              float64
 }
 
 // IntersectionNew : constructor for Intersect type
 func IntersectionNew(intersectionValue float64, shape Shape) Intersection {
-	return Intersection{intersectionValue, shape}
+	return Intersection{intersectionValue, shape, 0, 0}
+}
+
+// IntersectionWithUVNew : constructor for Intersect type, recording where on the
+// surface the intersection occurred
+func IntersectionWithUVNew(intersectionValue float64, shape Shape, u, v float64) Intersection {
+	return Intersection{intersectionValue, shape, u, v}
+}
+
+// IntersectionSort : sort intersections in place, ascending by intersectionValue
+func IntersectionSort(intersections []Intersection) {
+	sort.Slice(intersections, func(i, j int) bool {
+		return intersections[i].IntersectionValue < intersections[j].IntersectionValue
+	})
 }
 
 // IntersectionHit : the hit will always be the intersection with the lowest nonnegative t
 // value.
 func IntersectionHit(intersections []Intersection) (Intersection, error) {
 	// sort the intersections in ascending order by intersectionValue
-	sort.Slice(intersections, func(i, j int) bool {
-		return intersections[i].IntersectionValue < intersections[j].IntersectionValue
-	})
+	IntersectionSort(intersections)
 	// starting from the beginning, find the first non-neg intersection and return
 	for _, intersection := range intersections {
 		if !(intersection.IntersectionValue < 0.0) {
@@ -82,3 +259,112 @@ func IntersectionHit(intersections []Intersection) (Intersection, error) {
 	err := errors.New("No non-negative intersection found")
 	return IntersectionNew(0.0, nil), err
 }
+
+// Computations : state of a hit, precomputed for shading
+//
+// N1 and N2 are the refractive indices of the materials on either side of the
+// hit, the ray leaving N1 and entering N2
+type Computations struct {
+	IntersectionValue float64
+	Shape             Shape
+	Point             tuples.Tuple
+	OverPoint         tuples.Tuple
+	UnderPoint        tuples.Tuple
+	EyeV              tuples.Tuple
+	NormalV           tuples.Tuple
+	ReflectV          tuples.Tuple
+	Inside            bool
+	N1, N2            float64
+}
+
+// PrepareComputations : precompute the state of a hit for shading
+//
+// using variadic function to make the full, sorted intersection list optional.
+// it is needed to find which objects the hit lies inside of for refraction
+func PrepareComputations(hit Intersection, r rays.Ray, intersections ...Intersection) Computations {
+	var comps Computations
+	comps.IntersectionValue = hit.IntersectionValue
+	comps.Shape = hit.Shape
+	comps.Point = r.Position(hit.IntersectionValue)
+	comps.EyeV = r.Direction.Negate()
+	comps.NormalV = hit.Shape.NormalAt(comps.Point, hit)
+	// if the normal points away from the eye, the ray started inside the shape
+	if comps.NormalV.DotProduct(comps.EyeV) < 0 {
+		comps.Inside = true
+		comps.NormalV = comps.NormalV.Negate()
+	}
+	// reflect the ray's direction around the (possibly flipped) normal
+	comps.ReflectV = r.Direction.Reflect(comps.NormalV)
+	// “...bump the point just a bit in the direction of the normal before you
+	// test for shadows. This will move the point above the surface and prevent
+	// self-shadowing.”
+	comps.OverPoint = comps.Point.Add(comps.NormalV.ScalarMultiply(tuples.EPSILON))
+	// refracted rays originate just below the surface
+	comps.UnderPoint = comps.Point.Subtract(comps.NormalV.ScalarMultiply(tuples.EPSILON))
+	if len(intersections) == 0 {
+		intersections = []Intersection{hit}
+	}
+	comps.N1, comps.N2 = refractiveIndices(hit, intersections)
+	return comps
+}
+
+// refractiveIndices : find the refractive indices on either side of a hit
+//
+// walk the sorted intersections, tracking which objects the ray is inside of.
+// the last object entered before the hit gives n1, the last object still
+// entered after the hit gives n2
+func refractiveIndices(hit Intersection, intersections []Intersection) (float64, float64) {
+	n1, n2 := 1.0, 1.0
+	containers := []Shape{}
+	for _, i := range intersections {
+		isHit := i.IntersectionValue == hit.IntersectionValue && sameShape(i.Shape, hit.Shape)
+		if isHit && len(containers) > 0 {
+			n1 = containers[len(containers)-1].GetMaterial().RefractiveIndex
+		}
+		// entering an object adds it, exiting removes it
+		found := false
+		for j, c := range containers {
+			if sameShape(c, i.Shape) {
+				containers = append(containers[:j], containers[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			containers = append(containers, i.Shape)
+		}
+		if isHit {
+			if len(containers) > 0 {
+				n2 = containers[len(containers)-1].GetMaterial().RefractiveIndex
+			}
+			break
+		}
+	}
+	return n1, n2
+}
+
+// Schlick : approximate the fraction of light reflected at a hit
+//
+// “...the Fresnel effect. [...] it describes how the reflectance of a
+// transparent surface depends on the angle at which it is viewed.”
+func Schlick(comps Computations) float64 {
+	// find the cosine of the angle between the eye and normal vectors
+	cos := comps.EyeV.DotProduct(comps.NormalV)
+	// total internal reflection can only occur if n1 > n2
+	if comps.N1 > comps.N2 {
+		n := comps.N1 / comps.N2
+		sin2t := n * n * (1.0 - cos*cos)
+		if sin2t > 1.0 {
+			return 1.0
+		}
+		// when n1 > n2, use cos(theta_t) instead
+		cos = math.Sqrt(1.0 - sin2t)
+	}
+	r0 := math.Pow((comps.N1-comps.N2)/(comps.N1+comps.N2), 2)
+	return r0 + (1-r0)*math.Pow(1-cos, 5)
+}
+
+// Bounds : the object space bounds of the sphere
+func (s Sphere) Bounds() BoundingBox {
+	return BoundingBoxNew(tuples.PointNew(-1, -1, -1), tuples.PointNew(1, 1, 1))
+}
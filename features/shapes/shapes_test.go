@@ -2,6 +2,9 @@ package shapes
 
 import (
 	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/patterns"
 	"sarim-tracer/features/rays"
 	"sarim-tracer/features/transformations"
 	"sarim-tracer/features/tuples"
@@ -34,6 +37,30 @@ func TestSphereTransform(t *testing.T) {
 	}
 }
 
+func TestInverseTransformCached(t *testing.T) {
+	s := SphereNew(transformations.ScalingNew(2, 2, 2))
+	first := s.inverseTransform()
+	if first != s.inverseTransform() {
+		t.Errorf("got a new inverse on each call")
+	}
+	if !mat.EqualApprox(first, transformations.ScalingNew(0.5, 0.5, 0.5), tuples.EPSILON) {
+		t.Errorf("got %v want %v", first, transformations.ScalingNew(0.5, 0.5, 0.5))
+	}
+	// replacing or changing the transform afterwards still gives its inverse
+	s.Transform = transformations.TranslationNew(1, 2, 3)
+	if got := s.inverseTransform(); !mat.EqualApprox(got, transformations.TranslationNew(-1, -2, -3), tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, transformations.TranslationNew(-1, -2, -3))
+	}
+	s.Transform.Set(0, 3, 5)
+	if got := s.inverseTransform(); !mat.EqualApprox(got, transformations.TranslationNew(-5, -2, -3), tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, transformations.TranslationNew(-5, -2, -3))
+	}
+	// a sphere built without a constructor has no cache
+	if got := (Sphere{Transform: transformations.ScalingNew(4, 4, 4)}).inverseTransform(); !mat.EqualApprox(got, transformations.ScalingNew(0.25, 0.25, 0.25), tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, transformations.ScalingNew(0.25, 0.25, 0.25))
+	}
+}
+
 func TestScaledSphereIntersect(t *testing.T) {
 	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
 	s := SphereNew()
@@ -59,3 +86,280 @@ func TestTranslatedSphereIntersect(t *testing.T) {
 		t.Errorf("got %d want %d", len(intersections), 0)
 	}
 }
+
+func TestSphereNormalAt(t *testing.T) {
+	s := SphereNew()
+	got := s.NormalAt(tuples.PointNew(1, 0, 0))
+	want := tuples.VectorNew(1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	got = s.NormalAt(tuples.PointNew(0, 0, 1))
+	want = tuples.VectorNew(0, 0, 1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	v := math.Sqrt(3) / 3
+	got = s.NormalAt(tuples.PointNew(v, v, v))
+	want = tuples.VectorNew(v, v, v)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+	// the normal is a normalized vector
+	if !got.Equal(got.Normalize()) {
+		t.Errorf("got %v want %v", got, got.Normalize())
+	}
+}
+
+func TestTranslatedSphereNormalAt(t *testing.T) {
+	s := SphereNew(transformations.TranslationNew(0, 1, 0))
+	got := s.NormalAt(tuples.PointNew(0, 1.70711, -0.70711))
+	want := tuples.VectorNew(0, 0.70711, -0.70711)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestTransformedSphereNormalAt(t *testing.T) {
+	s := SphereNew(transformations.ChainTransform(
+		transformations.ScalingNew(1, 0.5, 1),
+		transformations.RotationZNew(math.Pi/5)))
+	got := s.NormalAt(tuples.PointNew(0, math.Sqrt(2)/2, -math.Sqrt(2)/2))
+	want := tuples.VectorNew(0, 0.97014, -0.24254)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestSphereMaterial(t *testing.T) {
+	s := SphereNew()
+	got := s.GetMaterial()
+	want := materials.MaterialNew()
+	if got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+	m := materials.MaterialNew()
+	m.Ambient = 1
+	s.Material = m
+	got = s.GetMaterial()
+	if got != m {
+		t.Errorf("got %v want %v", got, m)
+	}
+}
+
+func TestPrepareComputations(t *testing.T) {
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	s := SphereNew()
+	comps := PrepareComputations(IntersectionNew(4, s), r)
+	if !tuples.FloatEqual(comps.IntersectionValue, 4) {
+		t.Errorf("got %f want %f", comps.IntersectionValue, 4.0)
+	}
+	if !comps.Point.Equal(tuples.PointNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", comps.Point, tuples.PointNew(0, 0, -1))
+	}
+	if !comps.EyeV.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", comps.EyeV, tuples.VectorNew(0, 0, -1))
+	}
+	if !comps.NormalV.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", comps.NormalV, tuples.VectorNew(0, 0, -1))
+	}
+	if comps.Inside {
+		t.Errorf("got %v want %v", comps.Inside, false)
+	}
+}
+
+func TestPrepareComputationsInside(t *testing.T) {
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1))
+	s := SphereNew()
+	comps := PrepareComputations(IntersectionNew(1, s), r)
+	if !comps.Point.Equal(tuples.PointNew(0, 0, 1)) {
+		t.Errorf("got %v want %v", comps.Point, tuples.PointNew(0, 0, 1))
+	}
+	if !comps.EyeV.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", comps.EyeV, tuples.VectorNew(0, 0, -1))
+	}
+	if !comps.Inside {
+		t.Errorf("got %v want %v", comps.Inside, true)
+	}
+	// the normal is inverted to face the eye
+	if !comps.NormalV.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", comps.NormalV, tuples.VectorNew(0, 0, -1))
+	}
+}
+
+func TestPrepareComputationsOverPoint(t *testing.T) {
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	s := SphereNew(transformations.TranslationNew(0, 0, 1))
+	comps := PrepareComputations(IntersectionNew(5, s), r)
+	if !(comps.OverPoint.Z < -tuples.EPSILON/2) {
+		t.Errorf("got %f want < %f", comps.OverPoint.Z, -tuples.EPSILON/2)
+	}
+	if !(comps.Point.Z > comps.OverPoint.Z) {
+		t.Errorf("got %f want > %f", comps.Point.Z, comps.OverPoint.Z)
+	}
+}
+
+func TestPatternAtShape(t *testing.T) {
+	white := tuples.ColorNew(1, 1, 1)
+	black := tuples.ColorNew(0, 0, 0)
+
+	// stripes with an object transformation
+	s := SphereNew(transformations.ScalingNew(2, 2, 2))
+	p := patterns.StripeNew(white, black)
+	got := PatternAtShape(p, s, tuples.PointNew(1.5, 0, 0))
+	if !got.Equal(white) {
+		t.Errorf("got %v want %v", got, white)
+	}
+
+	// stripes with a pattern transformation
+	s = SphereNew()
+	p = patterns.StripeNew(white, black, transformations.ScalingNew(2, 2, 2))
+	got = PatternAtShape(p, s, tuples.PointNew(1.5, 0, 0))
+	if !got.Equal(white) {
+		t.Errorf("got %v want %v", got, white)
+	}
+
+	// stripes with both an object and a pattern transformation
+	s = SphereNew(transformations.ScalingNew(2, 2, 2))
+	p = patterns.StripeNew(white, black, transformations.TranslationNew(0.5, 0, 0))
+	got = PatternAtShape(p, s, tuples.PointNew(2.5, 0, 0))
+	if !got.Equal(white) {
+		t.Errorf("got %v want %v", got, white)
+	}
+}
+
+func TestPrepareComputationsReflectV(t *testing.T) {
+	p := PlaneNew()
+	r := rays.RayNew(tuples.PointNew(0, 1, -1), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	comps := PrepareComputations(IntersectionNew(math.Sqrt(2), p), r)
+	want := tuples.VectorNew(0, math.Sqrt(2)/2, math.Sqrt(2)/2)
+	if !comps.ReflectV.Equal(want) {
+		t.Errorf("got %v want %v", comps.ReflectV, want)
+	}
+}
+
+// glassSphereNew : a sphere with a glassy material
+func glassSphereNew(transform ...*mat.Dense) Sphere {
+	s := SphereNew(transform...)
+	s.Material.Transparency = 1.0
+	s.Material.RefractiveIndex = 1.5
+	return s
+}
+
+func TestPrepareComputationsRefractiveIndices(t *testing.T) {
+	a := glassSphereNew(transformations.ScalingNew(2, 2, 2))
+	a.Material.RefractiveIndex = 1.5
+	b := glassSphereNew(transformations.TranslationNew(0, 0, -0.25))
+	b.Material.RefractiveIndex = 2.0
+	c := glassSphereNew(transformations.TranslationNew(0, 0, 0.25))
+	c.Material.RefractiveIndex = 2.5
+	r := rays.RayNew(tuples.PointNew(0, 0, -4), tuples.VectorNew(0, 0, 1))
+	xs := []Intersection{
+		IntersectionNew(2, a),
+		IntersectionNew(2.75, b),
+		IntersectionNew(3.25, c),
+		IntersectionNew(4.75, b),
+		IntersectionNew(5.25, c),
+		IntersectionNew(6, a),
+	}
+	want := [][2]float64{
+		{1.0, 1.5},
+		{1.5, 2.0},
+		{2.0, 2.5},
+		{2.5, 2.5},
+		{2.5, 1.5},
+		{1.5, 1.0},
+	}
+	for i, hit := range xs {
+		comps := PrepareComputations(hit, r, xs...)
+		if !tuples.FloatEqual(comps.N1, want[i][0]) {
+			t.Errorf("%d: got n1 %f want %f", i, comps.N1, want[i][0])
+		}
+		if !tuples.FloatEqual(comps.N2, want[i][1]) {
+			t.Errorf("%d: got n2 %f want %f", i, comps.N2, want[i][1])
+		}
+	}
+}
+
+// two spheres built with the same fields are still two separate containers
+func TestPrepareComputationsRefractiveIndicesEqualShapes(t *testing.T) {
+	transform := transformations.ScalingNew(2, 2, 2)
+	a := SphereNew(transform)
+	a.Material.RefractiveIndex = 1.5
+	b := SphereNew(transform)
+	b.Material.RefractiveIndex = 1.5
+	r := rays.RayNew(tuples.PointNew(0, 0, -4), tuples.VectorNew(0, 0, 1))
+	xs := []Intersection{
+		IntersectionNew(2, a),
+		IntersectionNew(2, b),
+		IntersectionNew(6, a),
+		IntersectionNew(6, b),
+	}
+	comps := PrepareComputations(xs[2], r, xs...)
+	if !tuples.FloatEqual(comps.N1, 1.5) || !tuples.FloatEqual(comps.N2, 1.5) {
+		t.Errorf("got n1 %f n2 %f want %f %f", comps.N1, comps.N2, 1.5, 1.5)
+	}
+}
+
+// taggedSphere : a sphere that can't be compared with ==
+type taggedSphere struct {
+	Sphere
+	tags []string
+}
+
+func TestSameShapeNotComparable(t *testing.T) {
+	a := taggedSphere{SphereNew(), []string{"a"}}
+	b := taggedSphere{SphereNew(), []string{"b"}}
+	if !sameShape(a, a) {
+		t.Errorf("got a shape different from itself")
+	}
+	if sameShape(a, b) {
+		t.Errorf("got two shapes the same")
+	}
+}
+
+func TestPrepareComputationsUnderPoint(t *testing.T) {
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	s := glassSphereNew(transformations.TranslationNew(0, 0, 1))
+	hit := IntersectionNew(5, s)
+	comps := PrepareComputations(hit, r, hit)
+	if !(comps.UnderPoint.Z > tuples.EPSILON/2) {
+		t.Errorf("got %f want > %f", comps.UnderPoint.Z, tuples.EPSILON/2)
+	}
+	if !(comps.Point.Z < comps.UnderPoint.Z) {
+		t.Errorf("got %f want < %f", comps.Point.Z, comps.UnderPoint.Z)
+	}
+}
+
+func TestSchlickTotalInternalReflection(t *testing.T) {
+	s := glassSphereNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, math.Sqrt(2)/2), tuples.VectorNew(0, 1, 0))
+	xs := []Intersection{IntersectionNew(-math.Sqrt(2)/2, s), IntersectionNew(math.Sqrt(2)/2, s)}
+	comps := PrepareComputations(xs[1], r, xs...)
+	got := Schlick(comps)
+	if !tuples.FloatEqual(got, 1.0) {
+		t.Errorf("got %f want %f", got, 1.0)
+	}
+}
+
+func TestSchlickPerpendicular(t *testing.T) {
+	s := glassSphereNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 1, 0))
+	xs := []Intersection{IntersectionNew(-1, s), IntersectionNew(1, s)}
+	comps := PrepareComputations(xs[1], r, xs...)
+	got := Schlick(comps)
+	if !tuples.FloatEqual(got, 0.04) {
+		t.Errorf("got %f want %f", got, 0.04)
+	}
+}
+
+func TestSchlickSmallAngle(t *testing.T) {
+	s := glassSphereNew()
+	r := rays.RayNew(tuples.PointNew(0, 0.99, -2), tuples.VectorNew(0, 0, 1))
+	xs := []Intersection{IntersectionNew(1.8589, s)}
+	comps := PrepareComputations(xs[0], r, xs...)
+	got := Schlick(comps)
+	if math.Abs(got-0.48873) > 0.0001 {
+		t.Errorf("got %f want %f", got, 0.48873)
+	}
+}
@@ -0,0 +1,119 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/roots"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Torus : a Shape, a ring around the y axis in object space
+//
+// the center of the tube is MajorRadius away from the origin, and the tube
+// has a radius of MinorRadius
+type Torus struct {
+	Transform   *mat.Dense
+	Material    materials.Material
+	MajorRadius float64
+	MinorRadius float64
+	Parent      Shape
+	id          uint64
+	inverse     *inverseCache
+}
+
+// TorusNew : torus constructor
+//
+// using variadic function to make transform optional
+func TorusNew(majorRadius, minorRadius float64, transform ...*mat.Dense) Torus {
+	t := Torus{
+		Transform:   transformations.IdentityNew(4),
+		Material:    materials.MaterialNew(),
+		MajorRadius: majorRadius,
+		MinorRadius: minorRadius,
+	}
+	if len(transform) > 0 {
+		t.Transform = transform[0]
+	}
+	t.id = shapeIDNew()
+	t.inverse = inverseCacheNew(t.Transform)
+	return t
+}
+
+// Intersect torus with ray
+//
+// a point p is on the torus when
+// (|p|^2 + R^2 - r^2)^2 = 4 R^2 (x^2 + z^2), and substituting the ray gives a
+// quartic in t
+func (t Torus) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(t.inverseTransform())
+	// move the origin to the point on the ray closest to the center, so the
+	// coefficients stay small for rays starting far away
+	shift := -r.Direction.DotProduct(r.Origin.Subtract(tuples.PointNew(0, 0, 0))) /
+		r.Direction.DotProduct(r.Direction)
+	o := r.Position(shift).Subtract(tuples.PointNew(0, 0, 0))
+	d := r.Direction
+	R2 := t.MajorRadius * t.MajorRadius
+	r2 := t.MinorRadius * t.MinorRadius
+	dd := d.DotProduct(d)
+	od := o.DotProduct(d)
+	k := o.DotProduct(o) + R2 - r2
+	ts := roots.SolveQuartic(
+		dd*dd,
+		4*dd*od,
+		2*dd*k+4*od*od-4*R2*(d.X*d.X+d.Z*d.Z),
+		4*k*od-8*R2*(o.X*d.X+o.Z*d.Z),
+		k*k-4*R2*(o.X*o.X+o.Z*o.Z))
+	xs := []Intersection{}
+	for _, value := range ts {
+		xs = append(xs, IntersectionNew(value+shift, t))
+	}
+	return xs
+}
+
+// NormalAt : the normal is the gradient of the torus equation
+func (t Torus) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	p := WorldToObject(t, worldPoint)
+	R2 := t.MajorRadius * t.MajorRadius
+	s := p.X*p.X + p.Y*p.Y + p.Z*p.Z + R2 - t.MinorRadius*t.MinorRadius
+	objectNormal := tuples.VectorNew(p.X*(s-2*R2), p.Y*s, p.Z*(s-2*R2))
+	return NormalToWorld(t, objectNormal)
+}
+
+// Bounds : the object space bounds of the torus
+func (t Torus) Bounds() BoundingBox {
+	outer := t.MajorRadius + t.MinorRadius
+	return BoundingBoxNew(
+		tuples.PointNew(-outer, -t.MinorRadius, -outer),
+		tuples.PointNew(outer, t.MinorRadius, outer))
+}
+
+// GetMaterial : get the material of the torus
+func (t Torus) GetMaterial() materials.Material {
+	return t.Material
+}
+
+// GetTransform : get the transform of the torus
+func (t Torus) GetTransform() *mat.Dense {
+	return t.Transform
+}
+
+func (t Torus) inverseTransform() *mat.Dense {
+	return t.inverse.of(t.Transform)
+}
+
+func (t Torus) getID() uint64 {
+	return t.id
+}
+
+// GetParent : get the group or CSG containing the torus, if any
+func (t Torus) GetParent() Shape {
+	return t.Parent
+}
+
+func (t Torus) setParent(parent Shape) Shape {
+	t.Parent = parent
+	return t
+}
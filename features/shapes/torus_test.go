@@ -0,0 +1,80 @@
+package shapes
+
+import (
+	"math"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestTorusIntersect(t *testing.T) {
+	torus := TorusNew(2, 0.5)
+	tests := []struct {
+		name      string
+		origin    tuples.Tuple
+		direction tuples.Tuple
+		want      []float64
+	}{
+		{"through both sides of the ring", tuples.PointNew(-5, 0, 0), tuples.VectorNew(1, 0, 0), []float64{2.5, 3.5, 6.5, 7.5}},
+		{"through the hole", tuples.PointNew(0, 5, 0), tuples.VectorNew(0, -1, 0), []float64{}},
+		{"down through the tube", tuples.PointNew(2, 5, 0), tuples.VectorNew(0, -1, 0), []float64{4.5, 5.5}},
+		{"grazing the top", tuples.PointNew(-5, 0.5, 0), tuples.VectorNew(1, 0, 0), []float64{3, 3, 7, 7}},
+		{"far away", tuples.PointNew(-1e6, 0, 0), tuples.VectorNew(1, 0, 0), []float64{1e6 - 2.5, 1e6 - 1.5, 1e6 + 1.5, 1e6 + 2.5}},
+		{"miss", tuples.PointNew(-5, 1, 0), tuples.VectorNew(1, 0, 0), []float64{}},
+	}
+	for _, test := range tests {
+		xs := torus.Intersect(rays.RayNew(test.origin, test.direction))
+		if len(xs) != len(test.want) {
+			t.Errorf("%s: got %d want %d", test.name, len(xs), len(test.want))
+			continue
+		}
+		for i := range test.want {
+			if math.Abs(xs[i].IntersectionValue-test.want[i]) > 0.0001 {
+				t.Errorf("%s: got %f want %f", test.name, xs[i].IntersectionValue, test.want[i])
+			}
+		}
+	}
+}
+
+func TestTorusNormalAt(t *testing.T) {
+	torus := TorusNew(2, 0.5)
+	tests := []struct {
+		point tuples.Tuple
+		want  tuples.Tuple
+	}{
+		{tuples.PointNew(2.5, 0, 0), tuples.VectorNew(1, 0, 0)},
+		{tuples.PointNew(1.5, 0, 0), tuples.VectorNew(-1, 0, 0)},
+		{tuples.PointNew(0, 0.5, 2), tuples.VectorNew(0, 1, 0)},
+		{tuples.PointNew(0, 0, -2.5), tuples.VectorNew(0, 0, -1)},
+	}
+	for _, test := range tests {
+		got := torus.NormalAt(test.point)
+		if !got.Equal(test.want) {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestTransformedTorusIntersect(t *testing.T) {
+	// stand the torus up on its edge, facing the ray
+	torus := TorusNew(2, 0.5, transformations.RotationXNew(math.Pi/2))
+	xs := torus.Intersect(rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 0 {
+		t.Errorf("got %d want %d", len(xs), 0)
+	}
+	xs = torus.Intersect(rays.RayNew(tuples.PointNew(0, 2, -5), tuples.VectorNew(0, 0, 1)))
+	if len(xs) != 2 {
+		t.Fatalf("got %d want %d", len(xs), 2)
+	}
+	if math.Abs(xs[0].IntersectionValue-4.5) > 0.0001 || math.Abs(xs[1].IntersectionValue-5.5) > 0.0001 {
+		t.Errorf("got %v want %v", xs, []float64{4.5, 5.5})
+	}
+}
+
+func TestTorusBounds(t *testing.T) {
+	got := TorusNew(2, 0.5).Bounds()
+	if !got.Min.Equal(tuples.PointNew(-2.5, -0.5, -2.5)) || !got.Max.Equal(tuples.PointNew(2.5, 0.5, 2.5)) {
+		t.Errorf("got %v", got)
+	}
+}
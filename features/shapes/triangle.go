@@ -0,0 +1,210 @@
+package shapes
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// Triangle : a Shape, a flat triangle between three points in object space
+//
+// the edges and normal are precomputed by the constructor, so change the
+// points by constructing a new triangle
+type Triangle struct {
+	P1, P2, P3 tuples.Tuple
+	E1, E2     tuples.Tuple
+	Normal     tuples.Tuple
+	Transform  *mat.Dense
+	Material   materials.Material
+	Parent     Shape
+	id         uint64
+	inverse    *inverseCache
+}
+
+// TriangleNew : triangle constructor
+//
+// using variadic function to make transform optional
+func TriangleNew(p1, p2, p3 tuples.Tuple, transform ...*mat.Dense) Triangle {
+	t := Triangle{P1: p1, P2: p2, P3: p3}
+	t.E1 = p2.Subtract(p1)
+	t.E2 = p3.Subtract(p1)
+	t.Normal = t.E2.CrossProduct(t.E1).Normalize()
+	t.Transform = transformations.IdentityNew(4)
+	if len(transform) > 0 {
+		t.Transform = transform[0]
+	}
+	t.Material = materials.MaterialNew()
+	t.id = shapeIDNew()
+	t.inverse = inverseCacheNew(t.Transform)
+	return t
+}
+
+// Intersect triangle with ray
+func (t Triangle) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(t.inverseTransform())
+	value, u, v, ok := intersectTriangle(r, t.P1, t.E1, t.E2)
+	if !ok {
+		return []Intersection{}
+	}
+	return []Intersection{IntersectionWithUVNew(value, t, u, v)}
+}
+
+// intersectTriangle : intersect a ray with a triangle using the Möller–Trumbore
+// algorithm
+//
+// returns the intersection value and the barycentric u and v of the hit, and
+// false if the ray misses
+func intersectTriangle(r rays.Ray, p1, e1, e2 tuples.Tuple) (float64, float64, float64, bool) {
+	dirCrossE2 := r.Direction.CrossProduct(e2)
+	det := e1.DotProduct(dirCrossE2)
+	// the ray is parallel to the triangle
+	if math.Abs(det) < tuples.EPSILON {
+		return 0, 0, 0, false
+	}
+	f := 1.0 / det
+	p1ToOrigin := r.Origin.Subtract(p1)
+	u := f * p1ToOrigin.DotProduct(dirCrossE2)
+	// the ray misses by the p1-p3 edge
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	originCrossE1 := p1ToOrigin.CrossProduct(e1)
+	v := f * r.Direction.DotProduct(originCrossE1)
+	// the ray misses by the p1-p2 or p2-p3 edge
+	if v < 0 || (u+v) > 1 {
+		return 0, 0, 0, false
+	}
+	return f * e2.DotProduct(originCrossE1), u, v, true
+}
+
+// NormalAt : the triangle has the same normal everywhere
+func (t Triangle) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	return NormalToWorld(t, t.Normal)
+}
+
+// GetMaterial : get the material of the triangle
+func (t Triangle) GetMaterial() materials.Material {
+	return t.Material
+}
+
+// GetTransform : get the transform of the triangle
+func (t Triangle) GetTransform() *mat.Dense {
+	return t.Transform
+}
+
+func (t Triangle) inverseTransform() *mat.Dense {
+	return t.inverse.of(t.Transform)
+}
+
+func (t Triangle) getID() uint64 {
+	return t.id
+}
+
+// GetParent : get the group or CSG containing the triangle, if any
+func (t Triangle) GetParent() Shape {
+	return t.Parent
+}
+
+func (t Triangle) setParent(parent Shape) Shape {
+	t.Parent = parent
+	return t
+}
+
+// SmoothTriangle : a Shape, a triangle with a normal at each vertex
+//
+// the normal is interpolated across the surface from the vertex normals,
+// using the u and v of the hit, to make a mesh look smooth
+type SmoothTriangle struct {
+	P1, P2, P3 tuples.Tuple
+	N1, N2, N3 tuples.Tuple
+	E1, E2     tuples.Tuple
+	Transform  *mat.Dense
+	Material   materials.Material
+	Parent     Shape
+	id         uint64
+	inverse    *inverseCache
+}
+
+// SmoothTriangleNew : smooth triangle constructor
+//
+// using variadic function to make transform optional
+func SmoothTriangleNew(p1, p2, p3, n1, n2, n3 tuples.Tuple, transform ...*mat.Dense) SmoothTriangle {
+	t := SmoothTriangle{P1: p1, P2: p2, P3: p3, N1: n1, N2: n2, N3: n3}
+	t.E1 = p2.Subtract(p1)
+	t.E2 = p3.Subtract(p1)
+	t.Transform = transformations.IdentityNew(4)
+	if len(transform) > 0 {
+		t.Transform = transform[0]
+	}
+	t.Material = materials.MaterialNew()
+	t.id = shapeIDNew()
+	t.inverse = inverseCacheNew(t.Transform)
+	return t
+}
+
+// Intersect smooth triangle with ray
+func (t SmoothTriangle) Intersect(r rays.Ray) []Intersection {
+	// inverse transform the ray
+	r = r.Transform(t.inverseTransform())
+	value, u, v, ok := intersectTriangle(r, t.P1, t.E1, t.E2)
+	if !ok {
+		return []Intersection{}
+	}
+	return []Intersection{IntersectionWithUVNew(value, t, u, v)}
+}
+
+// NormalAt : interpolate the vertex normals using the u and v of the hit
+//
+// without a hit, the normal at the first vertex is used
+func (t SmoothTriangle) NormalAt(worldPoint tuples.Tuple, hit ...Intersection) tuples.Tuple {
+	if len(hit) == 0 {
+		return NormalToWorld(t, t.N1)
+	}
+	u, v := hit[0].U, hit[0].V
+	objectNormal := t.N2.ScalarMultiply(u).
+		Add(t.N3.ScalarMultiply(v)).
+		Add(t.N1.ScalarMultiply(1 - u - v))
+	return NormalToWorld(t, objectNormal)
+}
+
+// GetMaterial : get the material of the smooth triangle
+func (t SmoothTriangle) GetMaterial() materials.Material {
+	return t.Material
+}
+
+// GetTransform : get the transform of the smooth triangle
+func (t SmoothTriangle) GetTransform() *mat.Dense {
+	return t.Transform
+}
+
+func (t SmoothTriangle) inverseTransform() *mat.Dense {
+	return t.inverse.of(t.Transform)
+}
+
+func (t SmoothTriangle) getID() uint64 {
+	return t.id
+}
+
+// GetParent : get the group or CSG containing the smooth triangle, if any
+func (t SmoothTriangle) GetParent() Shape {
+	return t.Parent
+}
+
+func (t SmoothTriangle) setParent(parent Shape) Shape {
+	t.Parent = parent
+	return t
+}
+
+// Bounds : the object space bounds of the triangle
+func (t Triangle) Bounds() BoundingBox {
+	return BoundingBoxNew(t.P1, t.P2, t.P3)
+}
+
+// Bounds : the object space bounds of the smooth triangle
+func (t SmoothTriangle) Bounds() BoundingBox {
+	return BoundingBoxNew(t.P1, t.P2, t.P3)
+}
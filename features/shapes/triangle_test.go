@@ -0,0 +1,112 @@
+package shapes
+
+import (
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestTriangleNew(t *testing.T) {
+	p1 := tuples.PointNew(0, 1, 0)
+	p2 := tuples.PointNew(-1, 0, 0)
+	p3 := tuples.PointNew(1, 0, 0)
+	tri := TriangleNew(p1, p2, p3)
+	if !tri.E1.Equal(tuples.VectorNew(-1, -1, 0)) {
+		t.Errorf("got %v want %v", tri.E1, tuples.VectorNew(-1, -1, 0))
+	}
+	if !tri.E2.Equal(tuples.VectorNew(1, -1, 0)) {
+		t.Errorf("got %v want %v", tri.E2, tuples.VectorNew(1, -1, 0))
+	}
+	if !tri.Normal.Equal(tuples.VectorNew(0, 0, -1)) {
+		t.Errorf("got %v want %v", tri.Normal, tuples.VectorNew(0, 0, -1))
+	}
+}
+
+func TestTriangleNormalAt(t *testing.T) {
+	tri := TriangleNew(tuples.PointNew(0, 1, 0), tuples.PointNew(-1, 0, 0), tuples.PointNew(1, 0, 0))
+	for _, point := range []tuples.Tuple{
+		tuples.PointNew(0, 0.5, 0),
+		tuples.PointNew(-0.5, 0.75, 0),
+		tuples.PointNew(0.5, 0.25, 0),
+	} {
+		got := tri.NormalAt(point)
+		if !got.Equal(tri.Normal) {
+			t.Errorf("got %v want %v", got, tri.Normal)
+		}
+	}
+}
+
+func TestTriangleIntersectMiss(t *testing.T) {
+	tri := TriangleNew(tuples.PointNew(0, 1, 0), tuples.PointNew(-1, 0, 0), tuples.PointNew(1, 0, 0))
+	tests := []struct {
+		name      string
+		origin    tuples.Tuple
+		direction tuples.Tuple
+	}{
+		{"parallel", tuples.PointNew(0, -1, -2), tuples.VectorNew(0, 1, 0)},
+		{"p1-p3 edge", tuples.PointNew(1, 1, -2), tuples.VectorNew(0, 0, 1)},
+		{"p1-p2 edge", tuples.PointNew(-1, 1, -2), tuples.VectorNew(0, 0, 1)},
+		{"p2-p3 edge", tuples.PointNew(0, -1, -2), tuples.VectorNew(0, 0, 1)},
+	}
+	for _, test := range tests {
+		xs := tri.Intersect(rays.RayNew(test.origin, test.direction))
+		if len(xs) != 0 {
+			t.Errorf("%s: got %d want %d", test.name, len(xs), 0)
+		}
+	}
+}
+
+func TestTriangleIntersect(t *testing.T) {
+	tri := TriangleNew(tuples.PointNew(0, 1, 0), tuples.PointNew(-1, 0, 0), tuples.PointNew(1, 0, 0))
+	r := rays.RayNew(tuples.PointNew(0, 0.5, -2), tuples.VectorNew(0, 0, 1))
+	xs := tri.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].IntersectionValue, 2) {
+		t.Errorf("got %f want %f", xs[0].IntersectionValue, 2.0)
+	}
+}
+
+// smoothTriangleNew : the smooth triangle used by the tests below
+func smoothTriangleNew() SmoothTriangle {
+	return SmoothTriangleNew(
+		tuples.PointNew(0, 1, 0), tuples.PointNew(-1, 0, 0), tuples.PointNew(1, 0, 0),
+		tuples.VectorNew(0, 1, 0), tuples.VectorNew(-1, 0, 0), tuples.VectorNew(1, 0, 0))
+}
+
+func TestSmoothTriangleIntersectUV(t *testing.T) {
+	tri := smoothTriangleNew()
+	r := rays.RayNew(tuples.PointNew(-0.2, 0.3, -2), tuples.VectorNew(0, 0, 1))
+	xs := tri.Intersect(r)
+	if len(xs) != 1 {
+		t.Fatalf("got %d want %d", len(xs), 1)
+	}
+	if !tuples.FloatEqual(xs[0].U, 0.45) {
+		t.Errorf("got %f want %f", xs[0].U, 0.45)
+	}
+	if !tuples.FloatEqual(xs[0].V, 0.25) {
+		t.Errorf("got %f want %f", xs[0].V, 0.25)
+	}
+}
+
+func TestSmoothTriangleNormalAt(t *testing.T) {
+	tri := smoothTriangleNew()
+	hit := IntersectionWithUVNew(1, tri, 0.45, 0.25)
+	got := tri.NormalAt(tuples.PointNew(0, 0, 0), hit)
+	want := tuples.VectorNew(-0.5547, 0.83205, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestSmoothTrianglePrepareComputations(t *testing.T) {
+	tri := smoothTriangleNew()
+	hit := IntersectionWithUVNew(1, tri, 0.45, 0.25)
+	r := rays.RayNew(tuples.PointNew(-0.2, 0.3, -2), tuples.VectorNew(0, 0, 1))
+	comps := PrepareComputations(hit, r, hit)
+	want := tuples.VectorNew(-0.5547, 0.83205, 0)
+	if !comps.NormalV.Equal(want) {
+		t.Errorf("got %v want %v", comps.NormalV, want)
+	}
+}
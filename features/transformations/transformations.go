@@ -3,6 +3,7 @@ package transformations
 import (
 	"gonum.org/v1/gonum/mat"
 	"math"
+	"sarim-tracer/features/tuples"
 )
 
 // TranslationNew : construct a translation matrix
@@ -55,7 +56,7 @@ func RotationZNew(deg float64) *mat.Dense {
 	m.Zero()
 	m.SetRow(0, []float64{math.Cos(deg), -math.Sin(deg), 0, 0})
 	m.SetRow(1, []float64{math.Sin(deg), math.Cos(deg), 0, 0})
-	m.SetRow(2, []float64{0, 0, 0, 0})
+	m.SetRow(2, []float64{0, 0, 1, 0})
 	m.SetRow(3, []float64{0, 0, 0, 1})
 	return m
 }
@@ -94,3 +95,21 @@ func ChainTransform(transforms ...*mat.Dense) *mat.Dense {
 	}
 	return final
 }
+
+// ViewTransform : orient the world relative to an eye
+//
+// “...you specify where you want the eye to be in the scene (the from
+// parameter), the point in the scene at which you want to look (the to
+// parameter), and a vector indicating which direction is up.”
+func ViewTransform(from, to, up tuples.Tuple) *mat.Dense {
+	forward := to.Subtract(from).Normalize()
+	left := forward.CrossProduct(up.Normalize())
+	// up only needs to be approximately up, so recompute the true up
+	trueUp := left.CrossProduct(forward)
+	orientation := mat.NewDense(4, 4, nil)
+	orientation.SetRow(0, []float64{left.X, left.Y, left.Z, 0})
+	orientation.SetRow(1, []float64{trueUp.X, trueUp.Y, trueUp.Z, 0})
+	orientation.SetRow(2, []float64{-forward.X, -forward.Y, -forward.Z, 0})
+	orientation.SetRow(3, []float64{0, 0, 0, 1})
+	return ChainTransform(orientation, TranslationNew(-from.X, -from.Y, -from.Z))
+}
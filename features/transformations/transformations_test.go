@@ -1,6 +1,7 @@
 package transformations
 
 import (
+	"gonum.org/v1/gonum/mat"
 	"math"
 	"sarim-tracer/features/tuples"
 	"testing"
@@ -157,6 +158,16 @@ func TestRotationZ(t *testing.T) {
 	}
 }
 
+// rotating around z should leave the z component alone
+func TestRotationZPreservesZ(t *testing.T) {
+	p := tuples.PointNew(1, 0, 5)
+	got := tuples.TupleTransform(p, RotationZNew(math.Pi/2))
+	want := tuples.PointNew(0, 1, 5)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
 func TestShear(t *testing.T) {
 	transform := ShearNew(1, 0, 0, 0, 0, 0)
 	p := tuples.PointNew(2, 3, 4)
@@ -193,3 +204,52 @@ func TestChain(t *testing.T) {
 		t.Errorf("got %v want %v", got, want)
 	}
 }
+
+func TestViewTransformDefault(t *testing.T) {
+	from := tuples.PointNew(0, 0, 0)
+	to := tuples.PointNew(0, 0, -1)
+	up := tuples.VectorNew(0, 1, 0)
+	got := ViewTransform(from, to, up)
+	want := IdentityNew(4)
+	if !mat.EqualApprox(got, want, tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestViewTransformPositiveZ(t *testing.T) {
+	from := tuples.PointNew(0, 0, 0)
+	to := tuples.PointNew(0, 0, 1)
+	up := tuples.VectorNew(0, 1, 0)
+	got := ViewTransform(from, to, up)
+	want := ScalingNew(-1, 1, -1)
+	if !mat.EqualApprox(got, want, tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestViewTransformMovesWorld(t *testing.T) {
+	from := tuples.PointNew(0, 0, 8)
+	to := tuples.PointNew(0, 0, 0)
+	up := tuples.VectorNew(0, 1, 0)
+	got := ViewTransform(from, to, up)
+	want := TranslationNew(0, 0, -8)
+	if !mat.EqualApprox(got, want, tuples.EPSILON) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestViewTransformArbitrary(t *testing.T) {
+	from := tuples.PointNew(1, 3, 2)
+	to := tuples.PointNew(4, -2, 8)
+	up := tuples.VectorNew(1, 1, 0)
+	got := ViewTransform(from, to, up)
+	want := mat.NewDense(4, 4, []float64{
+		-0.50709, 0.50709, 0.67612, -2.36643,
+		0.76772, 0.60609, 0.12122, -2.82843,
+		-0.35857, 0.59761, -0.71714, 0.00000,
+		0.00000, 0.00000, 0.00000, 1.00000,
+	})
+	if !mat.EqualApprox(got, want, 0.0001) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
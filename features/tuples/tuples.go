@@ -226,6 +226,20 @@ func (t Tuple) CrossProduct(u Tuple) Tuple {
 	return VectorCrossProduct(t, u)
 }
 
+// VectorReflect : reflect a vector around a normal
+//
+// “...imagine bouncing a ball to your dog. You throw the ball to the ground at
+// a point halfway between you and the dog, the ball bounces up, and your dog
+// (if she is well trained) catches it.”
+func VectorReflect(in Tuple, normal Tuple) Tuple {
+	return in.Subtract(normal.ScalarMultiply(2 * in.DotProduct(normal)))
+}
+
+// Reflect : reflect a vector around a normal
+func (t Tuple) Reflect(normal Tuple) Tuple {
+	return VectorReflect(t, normal)
+}
+
 // TupleHadamardProduct : take the component-wise product
 func TupleHadamardProduct(a Tuple, b Tuple) Tuple {
 	return Tuple{a.X * b.X, a.Y * b.Y, a.Z * b.Z, a.W * b.W}
@@ -254,6 +268,16 @@ func ColorNew(r, g, b float64) Tuple {
 	return c
 }
 
+// ColorAdd : add two colors, keeping W at 1
+func ColorAdd(a, b Tuple) Tuple {
+	return ColorNew(a.X+b.X, a.Y+b.Y, a.Z+b.Z)
+}
+
+// ColorScalarMultiply : scale a color, keeping W at 1
+func ColorScalarMultiply(c Tuple, s float64) Tuple {
+	return ColorNew(c.X*s, c.Y*s, c.Z*s)
+}
+
 // FloatClamp : clamp a float between low and high
 func FloatClamp(f, low, high float64) float64 {
 	if f < low {
@@ -196,3 +196,40 @@ func TestTupleHadamardProduct(t *testing.T) {
 		t.Errorf("got %v want %v", got, want)
 	}
 }
+
+func TestVectorReflect(t *testing.T) {
+	v := VectorNew(1, -1, 0)
+	n := VectorNew(0, 1, 0)
+	got := v.Reflect(n)
+	want := VectorNew(1, 1, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	v = VectorNew(0, -1, 0)
+	n = VectorNew(math.Sqrt(2)/2, math.Sqrt(2)/2, 0)
+	got = v.Reflect(n)
+	want = VectorNew(1, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorAdd(t *testing.T) {
+	c1 := ColorNew(0.9, 0.6, 0.75)
+	c2 := ColorNew(0.7, 0.1, 0.25)
+	got := ColorAdd(c1, c2)
+	want := ColorNew(1.6, 0.7, 1.0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorScalarMultiply(t *testing.T) {
+	c := ColorNew(0.2, 0.3, 0.4)
+	got := ColorScalarMultiply(c, 2)
+	want := ColorNew(0.4, 0.6, 0.8)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
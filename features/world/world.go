@@ -0,0 +1,190 @@
+package world
+
+import (
+	"math"
+	"sarim-tracer/features/lights"
+	"sarim-tracer/features/materials"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+)
+
+// DefaultMaxDepth : how many times a ray may bounce before giving up
+var DefaultMaxDepth = 5
+
+// World : a collection of shapes and the lights illuminating them
+//
+// MaxDepth bounds the recursion of secondary rays, so that two facing mirrors
+// terminate
+type World struct {
+	Shapes   []shapes.Shape
+	Lights   []lights.PointLight
+	MaxDepth int
+}
+
+// WorldNew : create an empty world
+func WorldNew() World {
+	return World{[]shapes.Shape{}, []lights.PointLight{}, DefaultMaxDepth}
+}
+
+// remainingDepth : pick the optional recursion depth, defaulting to MaxDepth
+func remainingDepth(w World, remaining []int) int {
+	if len(remaining) == 0 {
+		return w.MaxDepth
+	}
+	return remaining[0]
+}
+
+// DefaultWorldNew : create a world with two concentric spheres and one light
+//
+// the outer sphere is a unit sphere, the inner sphere is scaled by half
+func DefaultWorldNew() World {
+	light := lights.PointLightNew(tuples.PointNew(-10, 10, -10), tuples.ColorNew(1, 1, 1))
+	s1 := shapes.SphereNew()
+	m := materials.MaterialNew()
+	m.Color = tuples.ColorNew(0.8, 1.0, 0.6)
+	m.Diffuse = 0.7
+	m.Specular = 0.2
+	s1.Material = m
+	s2 := shapes.SphereNew(transformations.ScalingNew(0.5, 0.5, 0.5))
+	return World{[]shapes.Shape{s1, s2}, []lights.PointLight{light}, DefaultMaxDepth}
+}
+
+// IntersectWorld : intersect a ray with every shape in the world
+//
+// the intersections are merged and sorted by intersectionValue
+func IntersectWorld(w World, r rays.Ray) []shapes.Intersection {
+	intersections := []shapes.Intersection{}
+	for _, s := range w.Shapes {
+		intersections = append(intersections, s.Intersect(r)...)
+	}
+	shapes.IntersectionSort(intersections)
+	return intersections
+}
+
+// Intersect : intersect a ray with every shape in the world
+func (w World) Intersect(r rays.Ray) []shapes.Intersection {
+	return IntersectWorld(w, r)
+}
+
+// ShadeHit : compute the color at a prepared hit, summed over every light
+//
+// using variadic function to make the remaining recursion depth optional
+func ShadeHit(w World, comps shapes.Computations, remaining ...int) tuples.Tuple {
+	depth := remainingDepth(w, remaining)
+	surface := tuples.ColorNew(0, 0, 0)
+	for _, light := range w.Lights {
+		inShadow := w.IsShadowed(light, comps.OverPoint)
+		shade := lights.Lighting(comps.Shape.GetMaterial(), comps.Shape, light, comps.OverPoint, comps.EyeV, comps.NormalV, inShadow)
+		surface = tuples.ColorAdd(surface, shade)
+	}
+	reflected := w.ReflectedColor(comps, depth)
+	refracted := w.RefractedColor(comps, depth)
+	// blend reflection and refraction by the Fresnel effect
+	material := comps.Shape.GetMaterial()
+	if material.Reflective > 0 && material.Transparency > 0 {
+		reflectance := shapes.Schlick(comps)
+		reflected = tuples.ColorScalarMultiply(reflected, reflectance)
+		refracted = tuples.ColorScalarMultiply(refracted, 1-reflectance)
+	}
+	return tuples.ColorAdd(tuples.ColorAdd(surface, reflected), refracted)
+}
+
+// ShadeHit : compute the color at a prepared hit, summed over every light
+func (w World) ShadeHit(comps shapes.Computations, remaining ...int) tuples.Tuple {
+	return ShadeHit(w, comps, remaining...)
+}
+
+// ReflectedColor : compute the color seen in a reflective surface
+//
+// “If the hit is on a reflective surface, you spawn a new ray at the point of
+// intersection, in the direction of the reflection vector, and find what
+// color it sees.”
+func ReflectedColor(w World, comps shapes.Computations, remaining ...int) tuples.Tuple {
+	depth := remainingDepth(w, remaining)
+	reflective := comps.Shape.GetMaterial().Reflective
+	if reflective == 0 || depth <= 0 {
+		return tuples.ColorNew(0, 0, 0)
+	}
+	reflectRay := rays.RayNew(comps.OverPoint, comps.ReflectV)
+	color := w.ColorAt(reflectRay, depth-1)
+	return tuples.ColorScalarMultiply(color, reflective)
+}
+
+// ReflectedColor : compute the color seen in a reflective surface
+func (w World) ReflectedColor(comps shapes.Computations, remaining ...int) tuples.Tuple {
+	return ReflectedColor(w, comps, remaining...)
+}
+
+// RefractedColor : compute the color seen through a transparent surface
+//
+// uses Snell's law to bend the ray as it passes from n1 into n2
+func RefractedColor(w World, comps shapes.Computations, remaining ...int) tuples.Tuple {
+	depth := remainingDepth(w, remaining)
+	black := tuples.ColorNew(0, 0, 0)
+	transparency := comps.Shape.GetMaterial().Transparency
+	if transparency == 0 || depth <= 0 {
+		return black
+	}
+	// find the ratio of the first index of refraction to the second
+	nRatio := comps.N1 / comps.N2
+	// cos(theta_i) is the dot product of the two vectors
+	cosI := comps.EyeV.DotProduct(comps.NormalV)
+	// find sin(theta_t)^2 via trigonometric identity
+	sin2t := nRatio * nRatio * (1 - cosI*cosI)
+	// total internal reflection
+	if sin2t > 1 {
+		return black
+	}
+	// find cos(theta_t) via trigonometric identity
+	cosT := math.Sqrt(1.0 - sin2t)
+	// compute the direction of the refracted ray
+	direction := comps.NormalV.ScalarMultiply(nRatio*cosI - cosT).Subtract(comps.EyeV.ScalarMultiply(nRatio))
+	refractRay := rays.RayNew(comps.UnderPoint, direction)
+	color := w.ColorAt(refractRay, depth-1)
+	return tuples.ColorScalarMultiply(color, transparency)
+}
+
+// RefractedColor : compute the color seen through a transparent surface
+func (w World) RefractedColor(comps shapes.Computations, remaining ...int) tuples.Tuple {
+	return RefractedColor(w, comps, remaining...)
+}
+
+// ColorAt : compute the color seen along a ray, black if nothing is hit
+//
+// using variadic function to make the remaining recursion depth optional
+func ColorAt(w World, r rays.Ray, remaining ...int) tuples.Tuple {
+	xs := w.Intersect(r)
+	hit, err := shapes.IntersectionHit(xs)
+	if err != nil {
+		return tuples.ColorNew(0, 0, 0)
+	}
+	return w.ShadeHit(shapes.PrepareComputations(hit, r, xs...), remainingDepth(w, remaining))
+}
+
+// ColorAt : compute the color seen along a ray, black if nothing is hit
+func (w World) ColorAt(r rays.Ray, remaining ...int) tuples.Tuple {
+	return ColorAt(w, r, remaining...)
+}
+
+// IsShadowed : check whether anything lies between a point and a light
+//
+// “...cast a ray, called a shadow ray, from each point of intersection toward
+// the light source. If something intersects that shadow ray between the point
+// and the light source, then the point is considered to be in shadow.”
+func IsShadowed(w World, light lights.PointLight, point tuples.Tuple) bool {
+	v := light.Position.Subtract(point)
+	distance := v.Magnitude()
+	r := rays.RayNew(point, v.Normalize())
+	hit, err := shapes.IntersectionHit(w.Intersect(r))
+	if err != nil {
+		return false
+	}
+	return hit.IntersectionValue < distance
+}
+
+// IsShadowed : check whether anything lies between a point and a light
+func (w World) IsShadowed(light lights.PointLight, point tuples.Tuple) bool {
+	return IsShadowed(w, light, point)
+}
@@ -0,0 +1,366 @@
+package world
+
+import (
+	"gonum.org/v1/gonum/mat"
+	"math"
+	"sarim-tracer/features/lights"
+	"sarim-tracer/features/rays"
+	"sarim-tracer/features/shapes"
+	"sarim-tracer/features/transformations"
+	"sarim-tracer/features/tuples"
+	"testing"
+)
+
+func TestWorldNew(t *testing.T) {
+	w := WorldNew()
+	if len(w.Shapes) != 0 {
+		t.Errorf("got %d want %d", len(w.Shapes), 0)
+	}
+	if len(w.Lights) != 0 {
+		t.Errorf("got %d want %d", len(w.Lights), 0)
+	}
+}
+
+func TestDefaultWorldNew(t *testing.T) {
+	w := DefaultWorldNew()
+	if len(w.Shapes) != 2 {
+		t.Errorf("got %d want %d", len(w.Shapes), 2)
+	}
+	if len(w.Lights) != 1 {
+		t.Errorf("got %d want %d", len(w.Lights), 1)
+	}
+	got := w.Lights[0].Position
+	want := tuples.PointNew(-10, 10, -10)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestIntersectWorld(t *testing.T) {
+	w := DefaultWorldNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := w.Intersect(r)
+	want := []float64{4, 4.5, 5.5, 6}
+	if len(xs) != len(want) {
+		t.Fatalf("got %d want %d", len(xs), len(want))
+	}
+	for i := range want {
+		if !tuples.FloatEqual(xs[i].IntersectionValue, want[i]) {
+			t.Errorf("got %f want %f", xs[i].IntersectionValue, want[i])
+		}
+	}
+}
+
+func TestShadeHit(t *testing.T) {
+	w := DefaultWorldNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	hit := shapes.IntersectionNew(4, w.Shapes[0])
+	got := w.ShadeHit(shapes.PrepareComputations(hit, r))
+	want := tuples.ColorNew(0.38066, 0.47583, 0.2855)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestShadeHitInside(t *testing.T) {
+	w := DefaultWorldNew()
+	w.Lights[0] = lights.PointLightNew(tuples.PointNew(0, 0.25, 0), tuples.ColorNew(1, 1, 1))
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1))
+	hit := shapes.IntersectionNew(0.5, w.Shapes[1])
+	got := w.ShadeHit(shapes.PrepareComputations(hit, r))
+	want := tuples.ColorNew(0.90498, 0.90498, 0.90498)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorAtMiss(t *testing.T) {
+	w := DefaultWorldNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 1, 0))
+	got := w.ColorAt(r)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorAtHit(t *testing.T) {
+	w := DefaultWorldNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	got := w.ColorAt(r)
+	want := tuples.ColorNew(0.38066, 0.47583, 0.2855)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// placing the default world's spheres through instances shouldn't change how
+// they shade
+func TestColorAtInstances(t *testing.T) {
+	w := DefaultWorldNew()
+	for i, s := range w.Shapes {
+		w.Shapes[i] = shapes.InstanceNew(s)
+	}
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	got := w.ColorAt(r)
+	want := tuples.ColorNew(0.38066, 0.47583, 0.2855)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorAtHitBehindRay(t *testing.T) {
+	w := DefaultWorldNew()
+	outer := w.Shapes[0].(shapes.Sphere)
+	outer.Material.Ambient = 1
+	w.Shapes[0] = outer
+	inner := w.Shapes[1].(shapes.Sphere)
+	inner.Material.Ambient = 1
+	w.Shapes[1] = inner
+	r := rays.RayNew(tuples.PointNew(0, 0, 0.75), tuples.VectorNew(0, 0, -1))
+	got := w.ColorAt(r)
+	want := inner.Material.Color
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestIsShadowed(t *testing.T) {
+	w := DefaultWorldNew()
+	light := w.Lights[0]
+	tests := []struct {
+		point tuples.Tuple
+		want  bool
+	}{
+		// nothing is collinear with point and light
+		{tuples.PointNew(0, 10, 0), false},
+		// an object is between the point and the light
+		{tuples.PointNew(10, -10, 10), true},
+		// the object is behind the light
+		{tuples.PointNew(-20, 20, -20), false},
+		// the object is behind the point
+		{tuples.PointNew(-2, 2, -2), false},
+	}
+	for _, test := range tests {
+		got := w.IsShadowed(light, test.point)
+		if got != test.want {
+			t.Errorf("%v: got %v want %v", test.point, got, test.want)
+		}
+	}
+}
+
+func TestShadeHitInShadow(t *testing.T) {
+	w := WorldNew()
+	w.Lights = append(w.Lights, lights.PointLightNew(tuples.PointNew(0, 0, -10), tuples.ColorNew(1, 1, 1)))
+	s1 := shapes.SphereNew()
+	s2 := shapes.SphereNew(transformations.TranslationNew(0, 0, 10))
+	w.Shapes = append(w.Shapes, s1, s2)
+	r := rays.RayNew(tuples.PointNew(0, 0, 5), tuples.VectorNew(0, 0, 1))
+	hit := shapes.IntersectionNew(4, s2)
+	got := w.ShadeHit(shapes.PrepareComputations(hit, r))
+	want := tuples.ColorNew(0.1, 0.1, 0.1)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestReflectedColorNonReflective(t *testing.T) {
+	w := DefaultWorldNew()
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 0, 1))
+	inner := w.Shapes[1].(shapes.Sphere)
+	inner.Material.Ambient = 1
+	w.Shapes[1] = inner
+	comps := shapes.PrepareComputations(shapes.IntersectionNew(1, inner), r)
+	got := w.ReflectedColor(comps)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// reflectivePlaneWorld : the default world with a half-reflective floor below
+// the spheres
+func reflectivePlaneWorld() (World, shapes.Plane) {
+	w := DefaultWorldNew()
+	p := shapes.PlaneNew(transformations.TranslationNew(0, -1, 0))
+	p.Material.Reflective = 0.5
+	w.Shapes = append(w.Shapes, p)
+	return w, p
+}
+
+func TestReflectedColorReflective(t *testing.T) {
+	w, p := reflectivePlaneWorld()
+	r := rays.RayNew(tuples.PointNew(0, 0, -3), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	comps := shapes.PrepareComputations(shapes.IntersectionNew(math.Sqrt(2), p), r)
+	got := w.ReflectedColor(comps)
+	want := tuples.ColorNew(0.19033, 0.23791, 0.14274)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestShadeHitReflective(t *testing.T) {
+	w, p := reflectivePlaneWorld()
+	r := rays.RayNew(tuples.PointNew(0, 0, -3), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	comps := shapes.PrepareComputations(shapes.IntersectionNew(math.Sqrt(2), p), r)
+	got := w.ShadeHit(comps)
+	want := tuples.ColorNew(0.87676, 0.92434, 0.82917)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestReflectedColorMaximumDepth(t *testing.T) {
+	w, p := reflectivePlaneWorld()
+	r := rays.RayNew(tuples.PointNew(0, 0, -3), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	comps := shapes.PrepareComputations(shapes.IntersectionNew(math.Sqrt(2), p), r)
+	got := w.ReflectedColor(comps, 0)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestColorAtMutuallyReflective(t *testing.T) {
+	w := WorldNew()
+	w.Lights = append(w.Lights, lights.PointLightNew(tuples.PointNew(0, 0, 0), tuples.ColorNew(1, 1, 1)))
+	lower := shapes.PlaneNew(transformations.TranslationNew(0, -1, 0))
+	lower.Material.Reflective = 1
+	upper := shapes.PlaneNew(transformations.TranslationNew(0, 1, 0))
+	upper.Material.Reflective = 1
+	w.Shapes = append(w.Shapes, lower, upper)
+	r := rays.RayNew(tuples.PointNew(0, 0, 0), tuples.VectorNew(0, 1, 0))
+	// this must terminate
+	w.ColorAt(r)
+}
+
+// testPattern : a pattern that returns the pattern space point as a color
+type testPattern struct{}
+
+func (p testPattern) PatternAt(patternPoint tuples.Tuple) tuples.Tuple {
+	return tuples.ColorNew(patternPoint.X, patternPoint.Y, patternPoint.Z)
+}
+
+func (p testPattern) GetTransform() *mat.Dense {
+	return transformations.IdentityNew(4)
+}
+
+func TestRefractedColorOpaque(t *testing.T) {
+	w := DefaultWorldNew()
+	s := w.Shapes[0]
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := []shapes.Intersection{shapes.IntersectionNew(4, s), shapes.IntersectionNew(6, s)}
+	comps := shapes.PrepareComputations(xs[0], r, xs...)
+	got := w.RefractedColor(comps, 5)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestRefractedColorMaximumDepth(t *testing.T) {
+	w := DefaultWorldNew()
+	s := w.Shapes[0].(shapes.Sphere)
+	s.Material.Transparency = 1.0
+	s.Material.RefractiveIndex = 1.5
+	w.Shapes[0] = s
+	r := rays.RayNew(tuples.PointNew(0, 0, -5), tuples.VectorNew(0, 0, 1))
+	xs := []shapes.Intersection{shapes.IntersectionNew(4, s), shapes.IntersectionNew(6, s)}
+	comps := shapes.PrepareComputations(xs[0], r, xs...)
+	got := w.RefractedColor(comps, 0)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestRefractedColorTotalInternalReflection(t *testing.T) {
+	w := DefaultWorldNew()
+	s := w.Shapes[0].(shapes.Sphere)
+	s.Material.Transparency = 1.0
+	s.Material.RefractiveIndex = 1.5
+	w.Shapes[0] = s
+	r := rays.RayNew(tuples.PointNew(0, 0, math.Sqrt(2)/2), tuples.VectorNew(0, 1, 0))
+	xs := []shapes.Intersection{
+		shapes.IntersectionNew(-math.Sqrt(2)/2, s),
+		shapes.IntersectionNew(math.Sqrt(2)/2, s),
+	}
+	// we're inside the sphere, so look at the second intersection
+	comps := shapes.PrepareComputations(xs[1], r, xs...)
+	got := w.RefractedColor(comps, 5)
+	want := tuples.ColorNew(0, 0, 0)
+	if !got.Equal(want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestRefractedColorRefractedRay(t *testing.T) {
+	w := DefaultWorldNew()
+	a := w.Shapes[0].(shapes.Sphere)
+	a.Material.Ambient = 1.0
+	a.Material.Pattern = testPattern{}
+	w.Shapes[0] = a
+	b := w.Shapes[1].(shapes.Sphere)
+	b.Material.Transparency = 1.0
+	b.Material.RefractiveIndex = 1.5
+	w.Shapes[1] = b
+	r := rays.RayNew(tuples.PointNew(0, 0, 0.1), tuples.VectorNew(0, 1, 0))
+	xs := []shapes.Intersection{
+		shapes.IntersectionNew(-0.9899, a),
+		shapes.IntersectionNew(-0.4899, b),
+		shapes.IntersectionNew(0.4899, b),
+		shapes.IntersectionNew(0.9899, a),
+	}
+	comps := shapes.PrepareComputations(xs[2], r, xs...)
+	got := w.RefractedColor(comps, 5)
+	want := tuples.ColorNew(0, 0.99888, 0.04725)
+	if !colorApprox(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// glassFloorWorld : the default world with a glass floor over a red ball
+func glassFloorWorld() (World, shapes.Plane) {
+	w := DefaultWorldNew()
+	floor := shapes.PlaneNew(transformations.TranslationNew(0, -1, 0))
+	floor.Material.Transparency = 0.5
+	floor.Material.RefractiveIndex = 1.5
+	ball := shapes.SphereNew(transformations.TranslationNew(0, -3.5, -0.5))
+	ball.Material.Color = tuples.ColorNew(1, 0, 0)
+	ball.Material.Ambient = 0.5
+	w.Shapes = append(w.Shapes, floor, ball)
+	return w, floor
+}
+
+func TestShadeHitTransparent(t *testing.T) {
+	w, floor := glassFloorWorld()
+	r := rays.RayNew(tuples.PointNew(0, 0, -3), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	xs := []shapes.Intersection{shapes.IntersectionNew(math.Sqrt(2), floor)}
+	comps := shapes.PrepareComputations(xs[0], r, xs...)
+	got := w.ShadeHit(comps, 5)
+	want := tuples.ColorNew(0.93642, 0.68642, 0.68642)
+	if !colorApprox(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestShadeHitReflectiveTransparent(t *testing.T) {
+	w, floor := glassFloorWorld()
+	floor.Material.Reflective = 0.5
+	w.Shapes[len(w.Shapes)-2] = floor
+	r := rays.RayNew(tuples.PointNew(0, 0, -3), tuples.VectorNew(0, -math.Sqrt(2)/2, math.Sqrt(2)/2))
+	xs := []shapes.Intersection{shapes.IntersectionNew(math.Sqrt(2), floor)}
+	comps := shapes.PrepareComputations(xs[0], r, xs...)
+	got := w.ShadeHit(comps, 5)
+	want := tuples.ColorNew(0.93391, 0.69643, 0.69243)
+	if !colorApprox(got, want) {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+// colorApprox : compare colors to the precision the reference values are given
+func colorApprox(a, b tuples.Tuple) bool {
+	return math.Abs(a.X-b.X) < 0.0001 &&
+		math.Abs(a.Y-b.Y) < 0.0001 &&
+		math.Abs(a.Z-b.Z) < 0.0001
+}